@@ -15,7 +15,8 @@ import (
 )
 
 type ErrorResponse struct {
-	Message string `json:"message"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"`
 }
 
 func GetUserFromContext(r *http.Request) (*entities.User, error) {
@@ -117,6 +118,11 @@ func DetectLanguage(r *http.Request) string {
 }
 
 func Error(w http.ResponseWriter, r *http.Request, err error, code int) {
+	ErrorDetails(w, r, err, code, nil)
+}
+
+// ErrorDetails responds like Error and adds machine readable details, e.g. a list of validation problems.
+func ErrorDetails(w http.ResponseWriter, r *http.Request, err error, code int, details interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.WriteHeader(code)
@@ -137,6 +143,7 @@ func Error(w http.ResponseWriter, r *http.Request, err error, code int) {
 	logErr := err
 	errorMsgJSON, marshalErr := json.Marshal(ErrorResponse{
 		Message: finalMsg,
+		Details: details,
 	})
 	if marshalErr != nil {
 		log.Println(marshalErr)
@@ -0,0 +1,124 @@
+package dtos
+
+import (
+	"dpv/dpv/src/domain/entities"
+	"time"
+)
+
+// CensusStatus is the reporting state of a club's census for a year
+type CensusStatus struct {
+	ClubKey     string     `json:"club_key"`
+	ClubName    string     `json:"club_name,omitempty"`
+	Year        int        `json:"year"`
+	Status      string     `json:"status"` // not_started, draft, submitted, accepted, returned, overdue
+	Due         *time.Time `json:"due,omitempty"`
+	MemberCount int        `json:"memberCount,omitempty"`
+	Comment     string     `json:"comment,omitempty"` // Reason the census was returned
+}
+
+// CensusFile describes how an uploaded census file was read
+type CensusFile struct {
+	Format    string `json:"format"` // csv, xlsx, ods
+	Sheet     string `json:"sheet,omitempty"`
+	Delimiter string `json:"delimiter,omitempty"`
+	Encoding  string `json:"encoding,omitempty"` // utf-8, utf-16, windows-1252, iso-8859-1
+	BOM       bool   `json:"bom,omitempty"`
+}
+
+// CensusProblem is an error or warning found in an uploaded census file
+type CensusProblem struct {
+	Line     int    `json:"line,omitempty"`   // 1-based row, omitted for the whole file
+	Column   int    `json:"column,omitempty"` // 1-based column, omitted for the whole row
+	Field    string `json:"field,omitempty"`  // firstname, lastname, birthyear, gender
+	Severity string `json:"severity"`         // error, warning
+	Message  string `json:"message"`
+}
+
+// CensusUpload is the result of a census upload together with the detected file dialect and all
+// problems found. The census is omitted if the file has errors.
+type CensusUpload struct {
+	*entities.Census
+	File     CensusFile      `json:"file"`
+	Problems []CensusProblem `json:"problems,omitempty"`
+	DryRun   bool            `json:"dryRun,omitempty"`
+}
+
+// GenderMigration reports the normalisation of stored census genders
+type GenderMigration struct {
+	Censuses int            `json:"censuses"` // censuses checked
+	Updated  int            `json:"updated"`  // censuses changed
+	Members  int            `json:"members"`  // members changed
+	Unknown  map[string]int `json:"unknown"`  // values left unchanged, with their count
+}
+
+// AgeBracketCount counts census members of an age bracket by gender
+type AgeBracketCount struct {
+	Label   string         `json:"label"` // e.g. "7-14" or "61+"
+	Min     int            `json:"min"`
+	Max     *int           `json:"max,omitempty"` // omitted for the open-ended last bracket
+	Genders map[string]int `json:"genders"`       // female, male, diverse, unspecified
+	Total   int            `json:"total"`
+}
+
+// CensusStatistics counts census members by age bracket and gender, for one club or aggregated
+type CensusStatistics struct {
+	Year      int               `json:"year"`
+	ClubKey   string            `json:"club_key,omitempty"`
+	ParentKey string            `json:"parent_key,omitempty"` // Landesverband whose subtree is aggregated
+	Clubs     int               `json:"clubs"`                // clubs with a census for the year
+	Brackets  []AgeBracketCount `json:"brackets"`
+	Genders   map[string]int    `json:"genders"`
+	Total     int               `json:"total"`
+}
+
+// CensusMemberChange is a member found in both censuses whose name or birth year was corrected
+type CensusMemberChange struct {
+	Before entities.MemberRow `json:"before"`
+	After  entities.MemberRow `json:"after"`
+	Fields []string           `json:"fields"` // firstname, lastname, birthyear
+}
+
+// CensusDiff compares a club's census with the one of an earlier year
+type CensusDiff struct {
+	ClubKey       string               `json:"club_key,omitempty"`
+	Year          int                  `json:"year"`
+	BaseYear      int                  `json:"baseYear"`
+	BaseCount     int                  `json:"baseCount"`
+	MemberCount   int                  `json:"memberCount"`
+	NetChange     int                  `json:"netChange"`
+	ChangePercent *float64             `json:"changePercent,omitempty"` // omitted if the base census is empty
+	Flagged       bool                 `json:"flagged"`                 // change above the swing threshold, to be reviewed
+	Unchanged     int                  `json:"unchanged"`
+	Added         []entities.MemberRow `json:"added"`
+	Removed       []entities.MemberRow `json:"removed"`
+	Corrections   []CensusMemberChange `json:"corrections"`
+}
+
+// CensusSwing is a club whose member count changed by more than the swing threshold since the previous year
+type CensusSwing struct {
+	ClubKey       string  `json:"club_key"`
+	ClubName      string  `json:"club_name,omitempty"`
+	Year          int     `json:"year"`
+	BaseCount     int     `json:"baseCount"`
+	MemberCount   int     `json:"memberCount"`
+	NetChange     int     `json:"netChange"`
+	ChangePercent float64 `json:"changePercent"`
+}
+
+// CensusMemberEntry is a census member together with the club that reported it
+type CensusMemberEntry struct {
+	ClubKey  string `json:"club_key"`
+	ClubName string `json:"club_name,omitempty"`
+	entities.MemberRow
+}
+
+// CensusDuplicate is a person counted in the censuses of several clubs in the same year
+type CensusDuplicate struct {
+	Firstname string              `json:"firstname"`
+	Lastname  string              `json:"lastname"`
+	BirthYear int                 `json:"birthYear"`
+	BirthDate string              `json:"birthDate,omitempty"`
+	Exact     bool                `json:"exact"` // false if the names only are similar
+	Clubs     int                 `json:"clubs"`
+	Entries   []CensusMemberEntry `json:"entries"`
+}
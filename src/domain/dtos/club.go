@@ -34,3 +34,15 @@ type ClubResponse struct {
 	WebsiteOK  bool    `json:"website_ok,omitempty"`
 	ParentKey  string  `json:"parent_key,omitempty"`
 }
+
+// PublicClub is a club entry in the public directory, containing only fields the club agreed to publish
+type PublicClub struct {
+	Key      string             `json:"_key"`
+	Name     string             `json:"name"`
+	City     string             `json:"city,omitempty"`
+	Website  string             `json:"website,omitempty"`
+	Email    string             `json:"email,omitempty"`
+	Parent   string             `json:"parent,omitempty"` // Name of the parent Landesverband
+	Location *entities.GeoPoint `json:"location,omitempty"`
+	Distance float64            `json:"distance,omitempty"` // Meters, only set for proximity searches
+}
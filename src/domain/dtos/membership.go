@@ -0,0 +1,12 @@
+package dtos
+
+import "time"
+
+// MembershipVerification is the public result of scanning a membership card
+type MembershipVerification struct {
+	Type       string    `json:"type"` // club or user
+	Name       string    `json:"name"`
+	Status     string    `json:"status"` // current membership status, archived for dissolved clubs
+	Active     bool      `json:"active"`
+	ValidUntil time.Time `json:"valid_until"` // expiry of the card
+}
@@ -0,0 +1,22 @@
+package dtos
+
+import "time"
+
+// CoachLicences is a coach of a club with the state of their licences
+type CoachLicences struct {
+	UserKey   string          `json:"user_key"`
+	FirstName string          `json:"firstname"`
+	LastName  string          `json:"lastname"`
+	Status    string          `json:"status"` // best licence state: valid, expiring, expired, unverified, rejected or none
+	Licences  []LicenceStatus `json:"licences"`
+}
+
+// LicenceStatus is the state of a single licence as shown to a club
+type LicenceStatus struct {
+	Key         string     `json:"_key"`
+	LicenceType string     `json:"licence_type"`
+	Number      string     `json:"number,omitempty"`
+	IssuingBody string     `json:"issuing_body"`
+	Expires     *time.Time `json:"expires"`
+	State       string     `json:"state"`
+}
@@ -1,15 +1,49 @@
 package entities
 
+import "time"
+
+// Canonical gender values of census members
+const (
+	GenderFemale      = "female"
+	GenderMale        = "male"
+	GenderDiverse     = "diverse"
+	GenderUnspecified = "unspecified"
+)
+
+// Genders lists the canonical gender values.
+var Genders = []string{GenderFemale, GenderMale, GenderDiverse, GenderUnspecified}
+
 type Census struct {
 	Entity
-	Year        int         `json:"year"`
-	MemberCount int         `json:"memberCount"`
-	Members     []MemberRow `json:"members"`
+	Year         int         `json:"year"`
+	MemberCount  int         `json:"memberCount"`
+	Members      []MemberRow `json:"members"`
+	Status       string      `json:"status"`  // draft, submitted, accepted, returned; empty before the workflow existed
+	Comment      string      `json:"comment"` // Reason given by the admin when returning the census
+	SubmitterKey string      `json:"submitter_key,omitempty"`
+	Submitted    time.Time   `json:"submitted"`
+	ReviewerKey  string      `json:"reviewer_key,omitempty"`
+	Reviewed     time.Time   `json:"reviewed"`
+}
+
+// State returns the workflow state of the census. Censuses stored before the workflow existed count as accepted.
+func (c *Census) State() string {
+	if c.Status == "" {
+		return CensusAccepted
+	}
+	return c.Status
+}
+
+// IsLocked reports whether the census is submitted or accepted, so board members can no longer change it.
+func (c *Census) IsLocked() bool {
+	state := c.State()
+	return state == CensusSubmitted || state == CensusAccepted
 }
 
 type MemberRow struct {
 	Firstname string `json:"firstname"`
 	Lastname  string `json:"lastname"`
-	Gender    string `json:"gender"`
+	Gender    string `json:"gender"` // one of Genders
 	BirthYear int    `json:"birthYear"`
+	BirthDate string `json:"birthDate,omitempty"` // Optional, YYYY-MM-DD
 }
@@ -0,0 +1,43 @@
+package entities
+
+import (
+	"strconv"
+	"time"
+)
+
+// Census status of a club for a reporting year. Draft, submitted, accepted and returned are
+// also the workflow states of a stored census.
+const (
+	CensusNotStarted = "not_started"
+	CensusDraft      = "draft"     // Uploaded by the board, not yet submitted
+	CensusSubmitted  = "submitted" // Submitted by the board, locked until reviewed
+	CensusAccepted   = "accepted"  // Accepted by an admin, counted in summaries and statistics
+	CensusReturned   = "returned"  // Returned by an admin with a comment, to be corrected and submitted again
+	CensusOverdue    = "overdue"
+)
+
+// CensusDeadline is the reporting deadline of a census year. Its key is the year.
+type CensusDeadline struct {
+	Entity
+	Year       int                  `json:"year"`
+	Due        time.Time            `json:"due"`                  // Last day for submissions
+	Extensions map[string]time.Time `json:"extensions,omitempty"` // Club key -> extended last day
+}
+
+func CensusDeadlineKey(year int) string {
+	return strconv.Itoa(year)
+}
+
+// DueFor returns the last day a club may submit its census, taking extensions into account.
+func (d *CensusDeadline) DueFor(clubKey string) time.Time {
+	if ext, ok := d.Extensions[clubKey]; ok && ext.After(d.Due) {
+		return ext
+	}
+	return d.Due
+}
+
+// IsClosedFor reports whether the deadline, including a granted extension, has passed for a club.
+// The due day itself is still open.
+func (d *CensusDeadline) IsClosedFor(clubKey string, now time.Time) bool {
+	return !now.Before(d.DueFor(clubKey).AddDate(0, 0, 1))
+}
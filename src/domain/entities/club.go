@@ -4,23 +4,34 @@ import "time"
 
 type Club struct {
 	Entity
-	Name                 string          `json:"name"`
-	LegalForm            string          `json:"legal_form"` // e.V., GmbH, etc.
-	Membership           Membership      `json:"membership"`
-	Members              int             `json:"members"` // Number of members for contribution calc
-	Votes                int             `json:"votes"`   // Votes in assembly, updated post-upload
-	ContactPerson        string          `json:"contact_person,omitempty"`
-	Email                string          `json:"email,omitempty"`
-	WebsiteOK            bool            `json:"website_ok"`
-	WebsiteVerification  time.Time       `json:"website_verification"`
-	ParentKey            string          `json:"parent_key,omitempty"` // For recursive SubsidiaryOf edge
-	OwnerKey             string          `json:"owner_key"`            // Initial creator (User key)
-	StatutesOK           bool            `json:"statutes_ok,omitempty"`
-	StatutesVerification time.Time       `json:"statutes_verification"`
-	RegistryOK           bool            `json:"registry_ok,omitempty"`
-	RegistryVerification time.Time       `json:"registry_verification"`
-	Vorstand             []VorstandUser  `json:"vorstand,omitempty"` // Populated via query, omitted if empty
-	Census               []CensusSummary `json:"census,omitempty"`   // Populated via query, omitted if empty
+	Name                  string          `json:"name"`
+	LegalForm             string          `json:"legal_form"` // e.V., GmbH, etc.
+	Membership            Membership      `json:"membership"`
+	Members               int             `json:"members"` // Number of members for contribution calc
+	Votes                 int             `json:"votes"`   // Votes in assembly, updated post-upload
+	ContactPerson         string          `json:"contact_person,omitempty"`
+	Email                 string          `json:"email,omitempty"`
+	City                  string          `json:"city"`
+	FederalState          string          `json:"federal_state"`  // ISO 3166-2 code without "DE-", e.g. BY; empty derives it from the postcode
+	PublicEmail           string          `json:"public_email"`   // Contact address shown in the public directory
+	PublicListing         bool            `json:"public_listing"` // Opt-in for the public directory
+	Location              *GeoPoint       `json:"location"`
+	LocationSource        string          `json:"location_source"` // manual, postcode
+	Website               string          `json:"website"`
+	WebsiteOK             bool            `json:"website_ok"`
+	WebsiteVerification   time.Time       `json:"website_verification"`
+	ParentKey             string          `json:"parent_key,omitempty"` // For recursive SubsidiaryOf edge
+	OwnerKey              string          `json:"owner_key"`            // Initial creator (User key)
+	StatutesOK            bool            `json:"statutes_ok"`
+	StatutesVerification  time.Time       `json:"statutes_verification"`
+	RegistryOK            bool            `json:"registry_ok"`
+	RegistryVerification  time.Time       `json:"registry_verification"`
+	TaxExemptOK           bool            `json:"tax_exempt_ok"`
+	TaxExemptVerification time.Time       `json:"tax_exempt_verification"`
+	Archived              *time.Time      `json:"archived"`           // Set when the club is dissolved, hides it from normal lists
+	ArchivedBy            string          `json:"archived_by"`        // User key of whoever dissolved the club
+	Vorstand              []VorstandUser  `json:"vorstand,omitempty"` // Populated via query, omitted if empty
+	Census                []CensusSummary `json:"census,omitempty"`   // Populated via query, omitted if empty
 }
 
 type CensusSummary struct {
@@ -0,0 +1,16 @@
+package entities
+
+import "time"
+
+// Document holds the metadata and review state of an uploaded club document.
+// The file itself lives in storage.Storage under the same filename.
+type Document struct {
+	Entity
+	Filename    string    `json:"filename"`
+	Category    string    `json:"category"` // statutes, registry, tax_exemption, other
+	Status      string    `json:"status"`   // pending, accepted, rejected
+	Comment     string    `json:"comment,omitempty"`
+	UploaderKey string    `json:"uploader_key,omitempty"`
+	ReviewerKey string    `json:"reviewer_key,omitempty"`
+	Reviewed    time.Time `json:"reviewed"`
+}
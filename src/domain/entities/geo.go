@@ -0,0 +1,19 @@
+package entities
+
+// GeoPoint is a GeoJSON point. Coordinates are ordered longitude, latitude.
+type GeoPoint struct {
+	Type        string     `json:"type"`
+	Coordinates [2]float64 `json:"coordinates"`
+}
+
+func NewGeoPoint(lat, lon float64) *GeoPoint {
+	return &GeoPoint{Type: "Point", Coordinates: [2]float64{lon, lat}}
+}
+
+func (p *GeoPoint) Lat() float64 {
+	return p.Coordinates[1]
+}
+
+func (p *GeoPoint) Lon() float64 {
+	return p.Coordinates[0]
+}
@@ -0,0 +1,35 @@
+package entities
+
+import "time"
+
+// Roles a club invitation grants once accepted
+const (
+	InvitationBoard = "vorstand"
+	InvitationCoach = "coach"
+)
+
+// Invitation is a pending request for a person to join a club board without an account yet,
+// or to be listed as a coach of the club.
+type Invitation struct {
+	Entity
+	ClubKey    string    `json:"club_key"`
+	Email      string    `json:"email"`
+	Role       string    `json:"role,omitempty"` // InvitationBoard or InvitationCoach, empty for older board invitations
+	InviterKey string    `json:"inviter_key"`
+	Expires    time.Time `json:"expires"`
+	Status     string    `json:"status"` // pending, accepted, revoked
+	AcceptedBy string    `json:"accepted_by,omitempty"`
+}
+
+// InvitedRole returns the role the invitation grants.
+func (i *Invitation) InvitedRole() string {
+	if i.Role == "" {
+		return InvitationBoard
+	}
+	return i.Role
+}
+
+// IsOpen reports whether the invitation can still be accepted.
+func (i *Invitation) IsOpen(now time.Time) bool {
+	return i.Status == "pending" && now.Before(i.Expires)
+}
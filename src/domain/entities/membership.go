@@ -1,11 +1,12 @@
 package entities
 
+// Membership is merged into the stored document on update, so its fields are not omitempty to let empty values clear them.
 type Membership struct {
-	IBAN              string  `json:"iban,omitempty"`
-	SEPAMandateNumber string  `json:"sepa_mandate_number,omitempty"`
+	IBAN              string  `json:"iban"`
+	SEPAMandateNumber string  `json:"sepa_mandate_number"`
 	Contribution      float64 `json:"contribution"`
 	Status            string  `json:"status"` // inactive, requested, active, denied, cancelled
-	Address           string  `json:"address,omitempty"`
+	Address           string  `json:"address"`
 }
 
 type MembershipProvider interface {
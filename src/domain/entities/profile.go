@@ -0,0 +1,28 @@
+package entities
+
+// Profile holds the type-specific attributes of a user profile.
+type Profile map[string]string
+
+// ProfileFields lists the attributes a user of the given profile type may set.
+// Types without an entry have no additional attributes.
+var ProfileFields = map[string][]string{
+	"athlete":     {"discipline", "team_key"},
+	"coach":       {"qualification", "license_number"},
+	"team":        {"team_name", "website"},
+	"group":       {"group_name", "city"},
+	"association": {"association_name", "registry_number"},
+	"freelancer":  {"business_name", "website"},
+	"company":     {"company_name", "registration_number", "vat_id"},
+	"school":      {"school_name", "school_type", "city"},
+	"government":  {"authority", "department"},
+}
+
+// AllowsField reports whether the profile type has the given attribute.
+func AllowsField(profileType, field string) bool {
+	for _, f := range ProfileFields[profileType] {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package entities
+
+import "time"
+
+// Licence state of a qualification as shown to clubs and admins
+const (
+	QualificationValid      = "valid"
+	QualificationExpiring   = "expiring"
+	QualificationExpired    = "expired"
+	QualificationUnverified = "unverified"
+	QualificationRejected   = "rejected"
+)
+
+// ExpiringWithin is the period before expiry in which a licence is reported as expiring.
+const ExpiringWithin = 90 * 24 * time.Hour
+
+// Qualification is a coach licence or trainer qualification of a user, linked by a qualification edge.
+// The certificate file lives in storage.Storage under the same filename.
+type Qualification struct {
+	Entity
+	LicenceType    string     `json:"licence_type"` // e.g. "DOSB Trainer C Parkour"
+	Number         string     `json:"number"`
+	IssuingBody    string     `json:"issuing_body"`
+	Issued         time.Time  `json:"issued"`
+	Expires        *time.Time `json:"expires"` // Last valid day, nil if the licence does not expire
+	RefresherHours int        `json:"refresher_hours"`
+	Certificate    string     `json:"certificate,omitempty"`
+	Status         string     `json:"status"` // pending, verified, rejected
+	Comment        string     `json:"comment,omitempty"`
+	VerifierKey    string     `json:"verifier_key,omitempty"`
+	Verified       time.Time  `json:"verified"`
+	Reminded       int        `json:"reminded"` // Smallest reminder stage in days already sent, 0 if none
+}
+
+// IsExpired reports whether the licence has expired. The expiry day itself is still valid.
+func (q *Qualification) IsExpired(now time.Time) bool {
+	return q.Expires != nil && !now.Before(q.Expires.AddDate(0, 0, 1))
+}
+
+// State returns the licence state of the qualification at the given time.
+func (q *Qualification) State(now time.Time) string {
+	switch {
+	case q.Status == "rejected":
+		return QualificationRejected
+	case q.Status != "verified":
+		return QualificationUnverified
+	case q.IsExpired(now):
+		return QualificationExpired
+	case q.Expires != nil && now.Add(ExpiringWithin).After(*q.Expires):
+		return QualificationExpiring
+	default:
+		return QualificationValid
+	}
+}
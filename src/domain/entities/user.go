@@ -12,6 +12,8 @@ type User struct {
 	EmailVerified *time.Time `json:"email_verified,omitempty"`
 	Membership    Membership `json:"membership"`
 	Language      string     `json:"language"`
+	Type          string     `json:"type"`              // one of settings.user_types
+	Profile       Profile    `json:"profile,omitempty"` // type-specific attributes, see ProfileFields
 }
 
 func (u *User) GetMembership() *Membership {
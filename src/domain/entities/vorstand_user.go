@@ -1,8 +1,37 @@
 package entities
 
+import "time"
+
+// Board functions stored on the authorizes edge
+const (
+	FunctionVorsitz       = "vorsitz"
+	FunctionStellvVorsitz = "stellv_vorsitz"
+	FunctionKassenwart    = "kassenwart"
+	FunctionJugendwart    = "jugendwart"
+	FunctionCustom        = "custom" // Title holds the name of the function
+)
+
+var BoardFunctions = []string{FunctionVorsitz, FunctionStellvVorsitz, FunctionKassenwart, FunctionJugendwart, FunctionCustom}
+
 // VorstandUser represents a minimal user for Vorstand display
 type VorstandUser struct {
-	Key       string `json:"_key"`
-	Firstname string `json:"firstname"`
-	Lastname  string `json:"lastname"`
+	Key       string     `json:"_key"`
+	Firstname string     `json:"firstname"`
+	Lastname  string     `json:"lastname"`
+	Function  string     `json:"function,omitempty"`
+	Title     string     `json:"title,omitempty"`
+	TermStart *time.Time `json:"term_start,omitempty"`
+	TermEnd   *time.Time `json:"term_end,omitempty"`
+}
+
+// InOffice reports whether the board member's term covers the given time.
+// The term end is the last day in office.
+func (v *VorstandUser) InOffice(at time.Time) bool {
+	if v.TermStart != nil && at.Before(*v.TermStart) {
+		return false
+	}
+	if v.TermEnd != nil && !at.Before(v.TermEnd.AddDate(0, 0, 1)) {
+		return false
+	}
+	return true
 }
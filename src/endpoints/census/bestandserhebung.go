@@ -0,0 +1,62 @@
+package census
+
+import (
+	"dpv/dpv/src/api"
+	"dpv/dpv/src/repository/geo"
+	"dpv/dpv/src/repository/t"
+	"dpv/dpv/src/service/census"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// Bestandserhebung exports the censuses of a year in the Landessportbund Bestandserhebung format (Admin only).
+// ?state=BY returns the CSV of one federal state, otherwise a zip archive with a CSV per state is returned.
+// ?sport= overrides the configured sport code.
+func (h *Handler) Bestandserhebung(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	if _, err := api.RequireGlobalAdmin(r, h.Service.Db); err != nil {
+		api.Error(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	year, err := strconv.Atoi(params.ByName("year"))
+	if err != nil {
+		api.Error(w, r, t.Errorf("invalid year: %v", err), http.StatusBadRequest)
+		return
+	}
+	query := r.URL.Query()
+	state := strings.ToUpper(strings.TrimPrefix(strings.TrimSpace(query.Get("state")), "DE-"))
+	if _, known := geo.FederalStates[state]; state != "" && !known {
+		api.Error(w, r, t.Errorf("unknown federal state '%s'", state), http.StatusBadRequest)
+		return
+	}
+	sportCode := census.SportCode()
+	if sport := strings.TrimSpace(query.Get("sport")); sport != "" {
+		sportCode = sport
+	}
+
+	byState, err := h.Service.Bestandserhebung(r.Context(), year)
+	if err != nil {
+		api.Error(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	if state != "" {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", census.BestandserhebungFilename(year, state)))
+		api.Success(w, r, census.BestandserhebungCSV(year, state, sportCode, byState[state]))
+		return
+	}
+
+	archive, err := census.BestandserhebungZip(year, sportCode, byState)
+	if err != nil {
+		api.Error(w, r, err, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"bestandserhebung_%d.zip\"", year))
+	api.Success(w, r, archive)
+}
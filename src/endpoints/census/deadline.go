@@ -0,0 +1,143 @@
+package census
+
+import (
+	"dpv/dpv/src/api"
+	"dpv/dpv/src/domain/entities"
+	"dpv/dpv/src/repository/t"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// Status returns the census reporting status of a club for a year.
+func (h *Handler) Status(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	year, err := strconv.Atoi(params.ByName("year"))
+	if err != nil {
+		api.Error(w, r, t.Errorf("invalid year: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	user, _ := r.Context().Value("user").(*entities.User)
+	status, err := h.Service.Status(r.Context(), params.ByName("key"), year, user)
+	if err != nil {
+		api.Error(w, r, err, http.StatusForbidden)
+		return
+	}
+
+	api.SuccessJson(w, r, status)
+}
+
+// Deadlines lists the census reporting deadlines.
+func (h *Handler) Deadlines(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	deadlines, err := h.Service.GetDeadlines(r.Context())
+	if err != nil {
+		api.Error(w, r, err, http.StatusInternalServerError)
+		return
+	}
+	if !isAdmin(r) {
+		// Extensions are granted per club and only visible to admins
+		for i := range deadlines {
+			deadlines[i].Extensions = nil
+		}
+	}
+
+	api.SuccessJson(w, r, deadlines)
+}
+
+// SetDeadline sets the reporting deadline of a census year (Admin only).
+func (h *Handler) SetDeadline(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	if _, err := api.RequireGlobalAdmin(r, h.Service.Db); err != nil {
+		api.Error(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	year, err := strconv.Atoi(params.ByName("year"))
+	if err != nil {
+		api.Error(w, r, t.Errorf("invalid year: %v", err), http.StatusBadRequest)
+		return
+	}
+	var req struct {
+		Due string `json:"due"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.Error(w, r, t.Errorf("invalid JSON body"), http.StatusBadRequest)
+		return
+	}
+	due, err := time.Parse(time.DateOnly, req.Due)
+	if err != nil {
+		api.Error(w, r, t.Errorf("invalid date %s, expected YYYY-MM-DD", req.Due), http.StatusBadRequest)
+		return
+	}
+
+	deadline, err := h.Service.SetDeadline(r.Context(), year, due)
+	if err != nil {
+		api.Error(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	api.SuccessJson(w, r, deadline)
+}
+
+// GrantExtension extends the reporting deadline of a year for one club (Admin only).
+func (h *Handler) GrantExtension(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	if _, err := api.RequireGlobalAdmin(r, h.Service.Db); err != nil {
+		api.Error(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	year, err := strconv.Atoi(params.ByName("year"))
+	if err != nil {
+		api.Error(w, r, t.Errorf("invalid year: %v", err), http.StatusBadRequest)
+		return
+	}
+	var req struct {
+		Until string `json:"until"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.Error(w, r, t.Errorf("invalid JSON body"), http.StatusBadRequest)
+		return
+	}
+	until, err := time.Parse(time.DateOnly, req.Until)
+	if err != nil {
+		api.Error(w, r, t.Errorf("invalid date %s, expected YYYY-MM-DD", req.Until), http.StatusBadRequest)
+		return
+	}
+
+	deadline, err := h.Service.GrantExtension(r.Context(), year, params.ByName("clubKey"), until)
+	if err != nil {
+		api.Error(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	api.SuccessJson(w, r, deadline)
+}
+
+// Overdue lists active clubs whose census for a year is overdue (Admin only).
+func (h *Handler) Overdue(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	if _, err := api.RequireGlobalAdmin(r, h.Service.Db); err != nil {
+		api.Error(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	year, err := strconv.Atoi(params.ByName("year"))
+	if err != nil {
+		api.Error(w, r, t.Errorf("invalid year: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	overdue, err := h.Service.Overdue(r.Context(), year)
+	if err != nil {
+		api.Error(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	api.SuccessJson(w, r, overdue)
+}
+
+func isAdmin(r *http.Request) bool {
+	user, _ := r.Context().Value("user").(*entities.User)
+	return user != nil && api.IsAdmin(*user)
+}
@@ -0,0 +1,79 @@
+package census
+
+import (
+	"dpv/dpv/src/api"
+	"dpv/dpv/src/domain/entities"
+	"dpv/dpv/src/repository/t"
+	"dpv/dpv/src/service/census"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// Diff compares a club's census with the one of the previous year, or of ?base= if given.
+func (h *Handler) Diff(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	clubKey := params.ByName("key")
+	year, err := strconv.Atoi(params.ByName("year"))
+	if err != nil {
+		api.Error(w, r, t.Errorf("invalid year: %v", err), http.StatusBadRequest)
+		return
+	}
+	baseYear := year - 1
+	if base := r.URL.Query().Get("base"); base != "" {
+		baseYear, err = strconv.Atoi(base)
+		if err != nil {
+			api.Error(w, r, t.Errorf("invalid year: %v", err), http.StatusBadRequest)
+			return
+		}
+	}
+	if baseYear >= year {
+		api.Error(w, r, t.Errorf("the base year must be before %d", year), http.StatusBadRequest)
+		return
+	}
+
+	user, _ := r.Context().Value("user").(*entities.User)
+	diff, err := h.Service.Diff(r.Context(), clubKey, year, baseYear, user)
+	if t.IsForbidden(err) {
+		api.Error(w, r, err, http.StatusForbidden)
+		return
+	} else if t.IsNotFound(err) {
+		// No census for the year or the base year
+		api.Error(w, r, err, http.StatusNotFound)
+		return
+	} else if err != nil {
+		api.Error(w, r, err, http.StatusInternalServerError)
+		return
+	}
+	api.SuccessJson(w, r, diff)
+}
+
+// Swings lists the clubs whose member count changed implausibly since the previous year (Admin only).
+// Use ?threshold=0.3 to flag changes above 30% instead of the configured threshold.
+func (h *Handler) Swings(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	if _, err := api.RequireGlobalAdmin(r, h.Service.Db); err != nil {
+		api.Error(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	year, err := strconv.Atoi(params.ByName("year"))
+	if err != nil {
+		api.Error(w, r, t.Errorf("invalid year: %v", err), http.StatusBadRequest)
+		return
+	}
+	threshold := census.SwingThreshold()
+	if value := r.URL.Query().Get("threshold"); value != "" {
+		threshold, err = strconv.ParseFloat(value, 64)
+		if err != nil || threshold < 0 {
+			api.Error(w, r, t.Errorf("invalid threshold '%s'", value), http.StatusBadRequest)
+			return
+		}
+	}
+
+	swings, err := h.Service.Swings(r.Context(), year, threshold)
+	if err != nil {
+		api.Error(w, r, err, http.StatusInternalServerError)
+		return
+	}
+	api.SuccessJson(w, r, swings)
+}
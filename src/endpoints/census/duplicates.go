@@ -0,0 +1,31 @@
+package census
+
+import (
+	"dpv/dpv/src/api"
+	"dpv/dpv/src/repository/t"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// Duplicates lists persons appearing in the censuses of several clubs in a year (Admin only).
+func (h *Handler) Duplicates(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	if _, err := api.RequireGlobalAdmin(r, h.Service.Db); err != nil {
+		api.Error(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	year, err := strconv.Atoi(params.ByName("year"))
+	if err != nil {
+		api.Error(w, r, t.Errorf("invalid year: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	duplicates, err := h.Service.Duplicates(r.Context(), year)
+	if err != nil {
+		api.Error(w, r, err, http.StatusInternalServerError)
+		return
+	}
+	api.SuccessJson(w, r, duplicates)
+}
@@ -0,0 +1,23 @@
+package census
+
+import (
+	"dpv/dpv/src/api"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// NormalizeGenders rewrites the genders of all stored censuses to their canonical values (Admin only).
+func (h *Handler) NormalizeGenders(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	if _, err := api.RequireGlobalAdmin(r, h.Service.Db); err != nil {
+		api.Error(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	result, err := h.Service.NormalizeGenders(r.Context())
+	if err != nil {
+		api.Error(w, r, err, http.StatusInternalServerError)
+		return
+	}
+	api.SuccessJson(w, r, result)
+}
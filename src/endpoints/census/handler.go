@@ -7,6 +7,7 @@ import (
 	"dpv/dpv/src/service/census"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/julienschmidt/httprouter"
@@ -60,6 +61,17 @@ func (h *Handler) Upsert(w http.ResponseWriter, r *http.Request, params httprout
 		return
 	}
 
+	// Only board members and admins may learn about the census, Upsert checks the deadline and workflow state
+	user, _ := r.Context().Value("user").(*entities.User)
+	if err := h.Service.Authorize(r.Context(), clubKey, user); t.IsForbidden(err) {
+		api.Error(w, r, err, http.StatusForbidden)
+		return
+	} else if err != nil {
+		api.Error(w, r, err, http.StatusInternalServerError)
+		return
+	}
+	dryRun := r.URL.Query().Get("dryRun") == "true"
+
 	file, _, err := r.FormFile("file")
 	if err != nil {
 		api.Error(w, r, t.Errorf("failed to get file: %v", err), http.StatusBadRequest)
@@ -67,26 +79,73 @@ func (h *Handler) Upsert(w http.ResponseWriter, r *http.Request, params httprout
 	}
 	defer file.Close()
 
-	// Parse and Validate
-	censusData, err := h.Service.ParseAndValidateCSV(file, year)
+	// Parse and Validate, CSV or the first (or named) sheet of an XLSX/ODS file, columns by header or mapping
+	mapping, err := census.ParseMapping(r.FormValue("mapping"))
 	if err != nil {
 		api.Error(w, r, err, http.StatusBadRequest)
 		return
 	}
+	upload, err := h.Service.ParseAndValidate(file, year, census.ParseOptions{
+		Sheet:   strings.TrimSpace(r.FormValue("sheet")),
+		Mapping: mapping,
+		Lang:    api.DetectLanguage(r),
+	})
+	if err != nil && upload != nil {
+		// All problems of the file with line, column and severity
+		api.ErrorDetails(w, r, err, http.StatusUnprocessableEntity, upload)
+		return
+	} else if err != nil {
+		api.Error(w, r, err, http.StatusBadRequest)
+		return
+	}
+	if dryRun {
+		upload.DryRun = true
+		api.SuccessJson(w, r, upload)
+		return
+	}
 
 	// Persist
-	user, _ := r.Context().Value("user").(*entities.User)
-	err = h.Service.Upsert(r.Context(), clubKey, censusData, user)
+	err = h.Service.Upsert(r.Context(), clubKey, upload.Census, user)
 	if err != nil {
-		api.Error(w, r, err, http.StatusInternalServerError)
+		// Locked census or closed year, or the census changed meanwhile
+		api.Error(w, r, err, errorStatus(err))
 		return
 	}
 
-	api.SuccessJson(w, r, censusData)
+	// The stored census together with the detected format, delimiter, encoding and warnings
+	api.SuccessJson(w, r, upload)
+}
+
+// errorStatus maps a census service error to the HTTP status of its cause, failures of the database
+// are internal errors.
+func errorStatus(err error) int {
+	switch {
+	case t.IsForbidden(err):
+		return http.StatusForbidden
+	case t.IsNotFound(err):
+		return http.StatusNotFound
+	case t.IsConflict(err):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
 }
 
 func (h *Handler) DownloadSample(w http.ResponseWriter, r *http.Request) {
 	lang := api.DetectLanguage(r)
+
+	if r.URL.Query().Get("format") == "xlsx" {
+		sampleData, err := h.Service.GenerateSampleXLSX(lang)
+		if err != nil {
+			api.Error(w, r, err, http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+		w.Header().Set("Content-Disposition", "attachment; filename=\"census_sample.xlsx\"")
+		w.Write(sampleData)
+		return
+	}
+
 	sampleData := h.Service.GenerateSampleCSV(lang)
 
 	w.Header().Set("Content-Type", "text/csv")
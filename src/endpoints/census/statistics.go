@@ -0,0 +1,90 @@
+package census
+
+import (
+	"dpv/dpv/src/api"
+	"dpv/dpv/src/domain/dtos"
+	"dpv/dpv/src/domain/entities"
+	"dpv/dpv/src/repository/t"
+	"dpv/dpv/src/service/census"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// ClubStatistics counts the census members of a club by age bracket and gender.
+// Use ?brackets=0,7,15 for other age brackets and ?format=csv for a CSV download.
+func (h *Handler) ClubStatistics(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	clubKey := params.ByName("key")
+	year, err := strconv.Atoi(params.ByName("year"))
+	if err != nil {
+		api.Error(w, r, t.Errorf("invalid year: %v", err), http.StatusBadRequest)
+		return
+	}
+	bounds, err := census.ParseAgeBrackets(r.URL.Query().Get("brackets"))
+	if err != nil {
+		api.Error(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	user, _ := r.Context().Value("user").(*entities.User)
+	stats, err := h.Service.ClubStatistics(r.Context(), clubKey, year, bounds, user)
+	if t.IsForbidden(err) {
+		api.Error(w, r, err, http.StatusForbidden)
+		return
+	} else if t.IsNotFound(err) {
+		api.Error(w, r, err, http.StatusNotFound)
+		return
+	} else if err != nil {
+		api.Error(w, r, err, http.StatusInternalServerError)
+		return
+	}
+	writeStatistics(w, r, stats, fmt.Sprintf("census_statistics_%s_%d.csv", clubKey, year))
+}
+
+// Statistics aggregates the census members of a year across all clubs by age bracket and gender (Admin only).
+// Use ?parent_key= to restrict it to a Landesverband and the clubs below it.
+func (h *Handler) Statistics(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	if _, err := api.RequireGlobalAdmin(r, h.Service.Db); err != nil {
+		api.Error(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	year, err := strconv.Atoi(params.ByName("year"))
+	if err != nil {
+		api.Error(w, r, t.Errorf("invalid year: %v", err), http.StatusBadRequest)
+		return
+	}
+	bounds, err := census.ParseAgeBrackets(r.URL.Query().Get("brackets"))
+	if err != nil {
+		api.Error(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	parentKey := r.URL.Query().Get("parent_key")
+	stats, err := h.Service.Statistics(r.Context(), year, parentKey, bounds)
+	if t.IsNotFound(err) {
+		api.Error(w, r, err, http.StatusNotFound)
+		return
+	} else if err != nil {
+		api.Error(w, r, err, http.StatusInternalServerError)
+		return
+	}
+	filename := fmt.Sprintf("census_statistics_%d.csv", year)
+	if parentKey != "" {
+		filename = fmt.Sprintf("census_statistics_%s_%d.csv", parentKey, year)
+	}
+	writeStatistics(w, r, stats, filename)
+}
+
+func writeStatistics(w http.ResponseWriter, r *http.Request, stats *dtos.CensusStatistics, filename string) {
+	if strings.ToLower(r.URL.Query().Get("format")) != "csv" {
+		api.SuccessJson(w, r, stats)
+		return
+	}
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	api.Success(w, r, census.StatisticsCSV(stats))
+}
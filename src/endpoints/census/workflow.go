@@ -0,0 +1,101 @@
+package census
+
+import (
+	"dpv/dpv/src/api"
+	"dpv/dpv/src/domain/entities"
+	"dpv/dpv/src/repository/t"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// Submit submits the club's census of a year for review by an admin.
+func (h *Handler) Submit(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	year, err := strconv.Atoi(params.ByName("year"))
+	if err != nil {
+		api.Error(w, r, t.Errorf("invalid year: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	user, _ := r.Context().Value("user").(*entities.User)
+	result, err := h.Service.Submit(r.Context(), params.ByName("key"), year, user)
+	if err != nil {
+		api.Error(w, r, err, errorStatus(err))
+		return
+	}
+	api.SuccessJson(w, r, result)
+}
+
+// Accept accepts a submitted census, so it is counted (Admin only).
+func (h *Handler) Accept(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	h.review(w, r, params, true)
+}
+
+// Return returns a census to the board with a comment (Admin only).
+func (h *Handler) Return(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	h.review(w, r, params, false)
+}
+
+func (h *Handler) review(w http.ResponseWriter, r *http.Request, params httprouter.Params, accepted bool) {
+	admin, err := api.RequireGlobalAdmin(r, h.Service.Db)
+	if err != nil {
+		api.Error(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	year, err := strconv.Atoi(params.ByName("year"))
+	if err != nil {
+		api.Error(w, r, t.Errorf("invalid year: %v", err), http.StatusBadRequest)
+		return
+	}
+	var req struct {
+		Comment string `json:"comment"`
+	}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			api.Error(w, r, t.Errorf("invalid JSON body"), http.StatusBadRequest)
+			return
+		}
+	}
+
+	comment := strings.TrimSpace(req.Comment)
+	if !accepted && comment == "" {
+		api.Error(w, r, t.Errorf("a comment is required when returning a census"), http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.Service.Review(r.Context(), params.ByName("key"), year, accepted, comment, admin)
+	if err != nil {
+		api.Error(w, r, err, errorStatus(err))
+		return
+	}
+	api.SuccessJson(w, r, result)
+}
+
+// ListByStatus lists the clubs whose census of a year is in a workflow state, "submitted" by default (Admin only).
+func (h *Handler) ListByStatus(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	if _, err := api.RequireGlobalAdmin(r, h.Service.Db); err != nil {
+		api.Error(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	year, err := strconv.Atoi(params.ByName("year"))
+	if err != nil {
+		api.Error(w, r, t.Errorf("invalid year: %v", err), http.StatusBadRequest)
+		return
+	}
+	status := r.URL.Query().Get("status")
+	if status == "" {
+		status = entities.CensusSubmitted
+	}
+
+	result, err := h.Service.ListByStatus(r.Context(), year, status)
+	if err != nil {
+		api.Error(w, r, err, http.StatusBadRequest)
+		return
+	}
+	api.SuccessJson(w, r, result)
+}
@@ -0,0 +1,44 @@
+package clubs
+
+import (
+	"dpv/dpv/src/api"
+	"dpv/dpv/src/repository/t"
+	"dpv/dpv/src/service/club"
+	"encoding/json"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// Bulk applies approve, deny, cancel or a field update to a list of clubs (Admin only).
+func (h *ClubHandler) Bulk(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	user, err := api.RequireGlobalAdmin(r, h.Service.DB)
+	if err != nil {
+		api.Error(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	var req club.BulkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.Error(w, r, t.Errorf("invalid JSON body"), http.StatusBadRequest)
+		return
+	}
+
+	results, committed, err := h.Service.Bulk(r.Context(), req, user)
+	if err != nil {
+		api.Error(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	lang := api.DetectLanguage(r)
+	for i := range results {
+		if results[i].Err != nil {
+			results[i].Error = t.T(results[i].Err, lang)
+		}
+	}
+
+	api.SuccessJson(w, r, map[string]interface{}{
+		"committed": committed,
+		"results":   results,
+	})
+}
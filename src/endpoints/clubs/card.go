@@ -0,0 +1,38 @@
+package clubs
+
+import (
+	"dpv/dpv/src/api"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// Card returns the membership card of an active club as PDF, or as PNG with ?format=png.
+func (h *ClubHandler) Card(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	user, err := api.GetUserFromContext(r)
+	if err != nil {
+		api.Error(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	card, err := h.Service.Card(r.Context(), ps.ByName("key"), user)
+	if err != nil {
+		api.Error(w, r, err, http.StatusForbidden)
+		return
+	}
+	format := strings.ToLower(r.URL.Query().Get("format"))
+	if format == "" {
+		format = "pdf"
+	}
+	content, contentType, err := card.Render(format)
+	if err != nil {
+		api.Error(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s-mitgliedsausweis.%s\"", api.SanitizeFilename(card.Name), format))
+	api.Success(w, r, content)
+}
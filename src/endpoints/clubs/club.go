@@ -25,6 +25,7 @@ type CreateClubRequest struct {
 	LegalForm string `json:"legal_form"`
 	Email     string `json:"email,omitempty"`
 	Address   string `json:"address,omitempty"`
+	Website   string `json:"website,omitempty"`
 }
 
 func (h *ClubHandler) Create(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
@@ -44,6 +45,7 @@ func (h *ClubHandler) Create(w http.ResponseWriter, r *http.Request, _ httproute
 	req.LegalForm = strings.TrimSpace(req.LegalForm)
 	req.Email = strings.TrimSpace(req.Email)
 	req.Address = strings.TrimSpace(req.Address)
+	req.Website = strings.TrimSpace(req.Website)
 
 	clubEntity := &entities.Club{
 		Name:      req.Name,
@@ -51,7 +53,8 @@ func (h *ClubHandler) Create(w http.ResponseWriter, r *http.Request, _ httproute
 		Membership: entities.Membership{
 			Address: req.Address,
 		},
-		Email: req.Email,
+		Email:   req.Email,
+		Website: req.Website,
 	}
 
 	err = h.Service.CreateClub(r.Context(), clubEntity, user.Key)
@@ -143,20 +146,31 @@ func FilteredResponse(clubEntity *entities.Club) *entities.Club {
 			Contribution: clubEntity.Membership.Contribution,
 			Address:      clubEntity.Membership.Address,
 		},
-		Members:              clubEntity.Members,
-		Votes:                clubEntity.Votes,
-		ContactPerson:        clubEntity.ContactPerson,
-		Email:                clubEntity.Email,
-		WebsiteOK:            clubEntity.WebsiteOK,
-		WebsiteVerification:  clubEntity.WebsiteVerification,
-		ParentKey:            clubEntity.ParentKey,
-		OwnerKey:             clubEntity.OwnerKey,
-		StatutesOK:           clubEntity.StatutesOK,
-		StatutesVerification: clubEntity.StatutesVerification,
-		RegistryOK:           clubEntity.RegistryOK,
-		RegistryVerification: clubEntity.RegistryVerification,
-		Vorstand:             clubEntity.Vorstand, // Include Vorstand info from query
-		Census:               clubEntity.Census,   // Include Census info from query
+		Members:               clubEntity.Members,
+		Votes:                 clubEntity.Votes,
+		ContactPerson:         clubEntity.ContactPerson,
+		Email:                 clubEntity.Email,
+		City:                  clubEntity.City,
+		FederalState:          clubEntity.FederalState,
+		PublicEmail:           clubEntity.PublicEmail,
+		PublicListing:         clubEntity.PublicListing,
+		Location:              clubEntity.Location,
+		LocationSource:        clubEntity.LocationSource,
+		Website:               clubEntity.Website,
+		WebsiteOK:             clubEntity.WebsiteOK,
+		WebsiteVerification:   clubEntity.WebsiteVerification,
+		ParentKey:             clubEntity.ParentKey,
+		OwnerKey:              clubEntity.OwnerKey,
+		StatutesOK:            clubEntity.StatutesOK,
+		StatutesVerification:  clubEntity.StatutesVerification,
+		RegistryOK:            clubEntity.RegistryOK,
+		RegistryVerification:  clubEntity.RegistryVerification,
+		TaxExemptOK:           clubEntity.TaxExemptOK,
+		TaxExemptVerification: clubEntity.TaxExemptVerification,
+		Archived:              clubEntity.Archived,
+		ArchivedBy:            clubEntity.ArchivedBy,
+		Vorstand:              clubEntity.Vorstand, // Include Vorstand info from query
+		Census:                clubEntity.Census,   // Include Census info from query
 	}
 	// Note: IBAN and SEPAMandatsnummer are omitted here for security/privacy in general views
 	return resp
@@ -183,11 +197,18 @@ func (h *ClubHandler) AddOwner(w http.ResponseWriter, r *http.Request, ps httpro
 		return
 	}
 
-	err = h.Service.AddOwner(r.Context(), key, req.Email, user)
+	invitation, err := h.Service.AddOwner(r.Context(), key, req.Email, user)
 	if err != nil {
 		api.Error(w, r, t.Errorf("could not add owner: %w", err), http.StatusBadRequest)
 		return
 	}
+	if invitation != nil {
+		api.SuccessJson(w, r, map[string]interface{}{
+			"message":    t.T(t.Errorf("Invitation sent to %s", invitation.Email), api.DetectLanguage(r)),
+			"invitation": invitation,
+		})
+		return
+	}
 	// Return updated club
 	club, _ := h.Service.GetClub(r.Context(), key, user)
 	api.SuccessJson(w, r, FilteredResponse(club))
@@ -212,3 +233,32 @@ func (h *ClubHandler) RemoveOwner(w http.ResponseWriter, r *http.Request, ps htt
 	club, _ := h.Service.GetClub(r.Context(), key, user)
 	api.SuccessJson(w, r, FilteredResponse(club))
 }
+
+// UpdateOwner changes the board function and term of an owner, omitted fields are kept
+func (h *ClubHandler) UpdateOwner(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	user, err := api.GetUserFromContext(r)
+	if err != nil {
+		api.Error(w, r, t.Errorf("failed to get user from context: %w", err), http.StatusUnauthorized)
+		return
+	}
+
+	key := ps.ByName("key")
+	var updates map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&updates); err != nil {
+		api.Error(w, r, t.Errorf("read request body failed: %w", err), http.StatusBadRequest)
+		return
+	}
+	for k, v := range updates {
+		if s, ok := v.(string); ok {
+			updates[k] = strings.TrimSpace(s)
+		}
+	}
+
+	if err := h.Service.UpdateBoardFunction(r.Context(), key, ps.ByName("userKey"), updates, user); err != nil {
+		api.Error(w, r, t.Errorf("could not update board function: %w", err), http.StatusBadRequest)
+		return
+	}
+	// Return updated club
+	club, _ := h.Service.GetClub(r.Context(), key, user)
+	api.SuccessJson(w, r, FilteredResponse(club))
+}
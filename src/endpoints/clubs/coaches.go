@@ -0,0 +1,76 @@
+package clubs
+
+import (
+	"dpv/dpv/src/api"
+	"dpv/dpv/src/repository/t"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// ListCoaches lists the coaches of a club with the state of their licences.
+func (h *ClubHandler) ListCoaches(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	user, err := api.GetUserFromContext(r)
+	if err != nil {
+		api.Error(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	coaches, err := h.Service.GetCoaches(r.Context(), ps.ByName("key"), user)
+	if err != nil {
+		api.Error(w, r, err, http.StatusForbidden)
+		return
+	}
+
+	api.SuccessJson(w, r, coaches)
+}
+
+// AddCoach invites a person to be listed as coach of a club. The response is the same whether or not the address is registered.
+func (h *ClubHandler) AddCoach(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	user, err := api.GetUserFromContext(r)
+	if err != nil {
+		api.Error(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		Email string `json:"email"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.Error(w, r, t.Errorf("read request body failed: %w", err), http.StatusBadRequest)
+		return
+	}
+	req.Email = strings.TrimSpace(req.Email)
+	if req.Email == "" {
+		api.Error(w, r, t.Errorf("email must not be empty"), http.StatusBadRequest)
+		return
+	}
+
+	invitation, err := h.Service.AddCoach(r.Context(), ps.ByName("key"), req.Email, user)
+	if err != nil {
+		api.Error(w, r, t.Errorf("could not add coach: %w", err), http.StatusBadRequest)
+		return
+	}
+	api.SuccessJson(w, r, map[string]interface{}{
+		"message":    t.T(t.Errorf("Invitation sent to %s", invitation.Email), api.DetectLanguage(r)),
+		"invitation": invitation,
+	})
+}
+
+// RemoveCoach removes a user from the coaches of a club.
+func (h *ClubHandler) RemoveCoach(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	user, err := api.GetUserFromContext(r)
+	if err != nil {
+		api.Error(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.Service.RemoveCoach(r.Context(), ps.ByName("key"), ps.ByName("userKey"), user); err != nil {
+		api.Error(w, r, t.Errorf("could not remove coach: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,133 @@
+package clubs
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"dpv/dpv/src/api"
+	"dpv/dpv/src/domain/dtos"
+	"dpv/dpv/src/domain/entities"
+	"dpv/dpv/src/repository/t"
+	"dpv/dpv/src/service/club"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// Directory lists active clubs that opted in to the public directory. No authentication required.
+// Use ?format=csv for a CSV download or ?format=geojson for a map-ready FeatureCollection; responses carry an ETag and may be cached for an hour.
+func (h *ClubHandler) Directory(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	entries, err := h.Service.GetPublicDirectory(r.Context())
+	if err != nil {
+		api.Error(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	var body []byte
+	contentType := "application/json"
+	switch strings.ToLower(r.URL.Query().Get("format")) {
+	case "csv":
+		body, err = directoryCSV(entries)
+		contentType = "text/csv; charset=utf-8"
+	case "geojson":
+		body, err = json.Marshal(directoryGeoJSON(entries))
+		contentType = "application/geo+json"
+	default:
+		body, err = json.Marshal(entries)
+	}
+	if err != nil {
+		api.Error(w, r, t.Errorf("serialising response failed: %w", err), http.StatusInternalServerError)
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	etag := `"` + base64.RawURLEncoding.EncodeToString(sum[:16]) + `"`
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Vary", "Accept-Encoding")
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	api.Success(w, r, body)
+}
+
+func directoryCSV(entries []dtos.PublicClub) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	writer.Write([]string{"name", "city", "website", "email", "parent"})
+	for _, e := range entries {
+		writer.Write([]string{e.Name, e.City, e.Website, e.Email, e.Parent})
+	}
+	writer.Flush()
+	return buffer.Bytes(), writer.Error()
+}
+
+// Nearby lists public directory entries around a point, nearest first. No authentication required.
+func (h *ClubHandler) Nearby(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	query := r.URL.Query()
+	if query.Get("lat") == "" || query.Get("lon") == "" {
+		api.Error(w, r, t.Errorf("missing required parameters"), http.StatusBadRequest)
+		return
+	}
+	lat, err := api.ParseFloat(query.Get("lat"))
+	if err != nil {
+		api.Error(w, r, t.Errorf("invalid latitude"), http.StatusBadRequest)
+		return
+	}
+	lon, err := api.ParseFloat(query.Get("lon"))
+	if err != nil {
+		api.Error(w, r, t.Errorf("invalid longitude"), http.StatusBadRequest)
+		return
+	}
+	radius := float64(club.DefaultNearbyRadius)
+	if query.Get("radius") != "" {
+		if radius, err = api.ParseFloat(query.Get("radius")); err != nil {
+			api.Error(w, r, t.Errorf("invalid radius"), http.StatusBadRequest)
+			return
+		}
+	}
+	limit, _ := api.ParseInt(query.Get("limit"))
+
+	entries, err := h.Service.FindNearby(r.Context(), lat, lon, radius, limit)
+	if err != nil {
+		api.Error(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	api.SuccessJson(w, r, entries)
+}
+
+// FeatureCollection is a GeoJSON feature collection of directory entries.
+type FeatureCollection struct {
+	Type     string    `json:"type"`
+	Features []Feature `json:"features"`
+}
+
+type Feature struct {
+	Type       string             `json:"type"`
+	Geometry   *entities.GeoPoint `json:"geometry"`
+	Properties dtos.PublicClub    `json:"properties"`
+}
+
+func directoryGeoJSON(entries []dtos.PublicClub) FeatureCollection {
+	collection := FeatureCollection{Type: "FeatureCollection", Features: []Feature{}}
+	for _, e := range entries {
+		if e.Location == nil {
+			continue
+		}
+		geometry := e.Location
+		e.Location = nil
+		collection.Features = append(collection.Features, Feature{
+			Type:       "Feature",
+			Geometry:   geometry,
+			Properties: e,
+		})
+	}
+	return collection
+}
@@ -4,10 +4,12 @@ import (
 	"archive/zip"
 	"dpv/dpv/src/api"
 	"dpv/dpv/src/repository/t"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"strings"
 
 	"github.com/julienschmidt/httprouter"
 )
@@ -43,16 +45,17 @@ func (h *ClubHandler) UploadDocument(w http.ResponseWriter, r *http.Request, ps
 	}
 	defer file.Close()
 
-	// Save using storage service
-	filename, err := h.Service.Storage.SaveDocument("clubs", key, header.Filename, file)
+	category := strings.TrimSpace(r.FormValue("category"))
+	doc, err := h.Service.UploadDocument(r.Context(), key, category, header.Filename, file, user)
 	if err != nil {
-		api.Error(w, r, t.Errorf("save document failed: %w", err), http.StatusInternalServerError)
+		api.Error(w, r, err, http.StatusBadRequest)
 		return
 	}
 
 	api.SuccessJson(w, r, map[string]string{
 		"message":  t.T(t.Errorf("document uploaded successfully"), api.DetectLanguage(r)),
-		"filename": filename,
+		"filename": doc.Filename,
+		"category": doc.Category,
 	})
 }
 
@@ -74,15 +77,60 @@ func (h *ClubHandler) ListDocuments(w http.ResponseWriter, r *http.Request, ps h
 		return
 	}
 
-	files, err := h.Service.Storage.ListDocuments("clubs", key)
+	files, err := h.Service.ListDocuments(r.Context(), key)
 	if err != nil {
-		api.Error(w, r, t.Errorf("list documents failed: %w", err), http.StatusInternalServerError)
+		api.Error(w, r, err, http.StatusInternalServerError)
 		return
 	}
 
 	api.SuccessJson(w, r, files)
 }
 
+// AcceptDocument marks a club document as verified (Admin only).
+func (h *ClubHandler) AcceptDocument(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	h.reviewDocument(w, r, ps, true)
+}
+
+// RejectDocument marks a club document as rejected (Admin only).
+func (h *ClubHandler) RejectDocument(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	h.reviewDocument(w, r, ps, false)
+}
+
+func (h *ClubHandler) reviewDocument(w http.ResponseWriter, r *http.Request, ps httprouter.Params, accepted bool) {
+	admin, err := api.RequireGlobalAdmin(r, h.Service.DB)
+	if err != nil {
+		api.Error(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		Comment string `json:"comment"`
+	}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			api.Error(w, r, t.Errorf("invalid JSON body"), http.StatusBadRequest)
+			return
+		}
+	}
+
+	comment := strings.TrimSpace(req.Comment)
+	if !accepted && comment == "" {
+		api.Error(w, r, t.Errorf("a comment is required when rejecting a document"), http.StatusBadRequest)
+		return
+	}
+
+	doc, err := h.Service.ReviewDocument(r.Context(), ps.ByName("key"), ps.ByName("filename"), accepted, comment, admin)
+	if t.IsNotFound(err) {
+		api.Error(w, r, err, http.StatusNotFound)
+		return
+	} else if err != nil {
+		api.Error(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	api.SuccessJson(w, r, doc)
+}
+
 // GetDocument serves a document for a club.
 func (h *ClubHandler) GetDocument(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	user, err := api.GetUserFromContext(r)
@@ -0,0 +1,183 @@
+package clubs
+
+import (
+	"dpv/dpv/src/api"
+	"dpv/dpv/src/domain/entities"
+	"dpv/dpv/src/repository/t"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// ListInvitations lists pending board invitations of a club.
+func (h *ClubHandler) ListInvitations(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	user, err := api.GetUserFromContext(r)
+	if err != nil {
+		api.Error(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	invitations, err := h.Service.ListInvitations(r.Context(), ps.ByName("key"), user)
+	if err != nil {
+		api.Error(w, r, err, http.StatusForbidden)
+		return
+	}
+
+	api.SuccessJson(w, r, invitations)
+}
+
+// RevokeInvitation withdraws a pending board invitation.
+func (h *ClubHandler) RevokeInvitation(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	user, err := api.GetUserFromContext(r)
+	if err != nil {
+		api.Error(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	err = h.Service.RevokeInvitation(r.Context(), ps.ByName("key"), ps.ByName("invitationKey"), user)
+	if err != nil {
+		api.Error(w, r, t.Errorf("could not revoke invitation: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AcceptInvitation adds the authenticated user to the board or the coaches of the invited club.
+func (h *ClubHandler) AcceptInvitation(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	user, err := api.GetUserFromContext(r)
+	if err != nil {
+		api.Error(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		Token string `json:"token"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.Error(w, r, t.Errorf("invalid JSON body"), http.StatusBadRequest)
+		return
+	}
+
+	invitation, err := h.Service.AcceptInvitation(r.Context(), ps.ByName("key"), req.Token, user)
+	if err != nil {
+		api.Error(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	club, _ := h.Service.GetClub(r.Context(), invitation.ClubKey, user)
+	api.SuccessJson(w, r, FilteredResponse(club))
+}
+
+// ShowInvitation - public: show a page to log in or register and accept a board or coach invitation
+func (h *ClubHandler) ShowInvitation(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	key := ps.ByName("key")
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		api.Error(w, r, t.Errorf("missing required parameters"), http.StatusBadRequest)
+		return
+	}
+
+	invitation, err := h.Service.GetInvitation(r.Context(), key, token)
+	if err != nil {
+		api.Error(w, r, err, http.StatusBadRequest)
+		return
+	}
+	clubName := ""
+	if club, err := h.Service.DB.GetClubByKey(r.Context(), invitation.ClubKey); err == nil {
+		clubName = club.Name
+	}
+
+	title := "Einladung in den Vorstand"
+	invited := fmt.Sprintf("den Verein <strong>%s</strong> als Vorstandsmitglied zu vertreten", html.EscapeString(clubName))
+	accepted := "Sie sind jetzt Vorstandsmitglied."
+	if invitation.InvitedRole() == entities.InvitationCoach {
+		title = "Einladung als Trainer*in"
+		invited = fmt.Sprintf("beim Verein <strong>%s</strong> als Trainer*in eingetragen zu werden. Der Verein sieht dann Ihre Trainerlizenzen und deren Gültigkeit", html.EscapeString(clubName))
+		accepted = "Sie sind jetzt als Trainer*in eingetragen."
+	}
+
+	jsKey, _ := json.Marshal(key)
+	jsToken, _ := json.Marshal(token)
+	jsAccepted, _ := json.Marshal("✅ Einladung angenommen! " + accepted)
+
+	page := fmt.Sprintf(`<!DOCTYPE html>
+<html lang="de">
+<head>
+    <meta charset="UTF-8">
+    <title>%s - DPV</title>
+    <style>
+        body { font-family: Arial, sans-serif; max-width: 400px; margin: 50px auto; padding: 20px; }
+        input, button { width: 100%%; padding: 10px; margin: 8px 0; box-sizing: border-box; }
+        .register { display: none; }
+    </style>
+</head>
+<body>
+    <h1>🤝 %s</h1>
+    <p>Sie wurden eingeladen, %s.</p>
+    <form id="acceptForm">
+        <label><input type="checkbox" id="isNew" style="width: auto"> Ich habe noch kein Konto</label>
+        <div class="register">
+            <label for="firstname">Vorname:</label>
+            <input type="text" id="firstname">
+            <label for="lastname">Nachname:</label>
+            <input type="text" id="lastname">
+        </div>
+        <label for="email">E-Mail-Adresse:</label>
+        <input type="email" id="email" value="%s" required>
+        <label for="password">Passwort:</label>
+        <input type="password" id="password" required>
+        <button type="submit">Einladung annehmen</button>
+    </form>
+    <div id="result"></div>
+    <script>
+      const key = %s, token = %s, accepted = %s;
+      document.getElementById('isNew').onchange = function() {
+        document.querySelector('.register').style.display = this.checked ? 'block' : 'none';
+      };
+      document.getElementById('acceptForm').onsubmit = async function(e) {
+        e.preventDefault();
+        const email = document.getElementById('email').value;
+        const password = document.getElementById('password').value;
+        const resultDiv = document.getElementById('result');
+        resultDiv.textContent = '';
+        if (document.getElementById('isNew').checked) {
+          const reg = await fetch('/dpv/users', {
+            method: 'POST',
+            headers: { 'Content-Type': 'application/json' },
+            body: JSON.stringify({ email, password,
+              firstname: document.getElementById('firstname').value,
+              lastname: document.getElementById('lastname').value })
+          });
+          if (!reg.ok) {
+            const data = await reg.json();
+            resultDiv.textContent = 'Fehler: ' + (data.message || 'Unbekannter Fehler');
+            return;
+          }
+          resultDiv.textContent = 'Konto angelegt. Bitte bestätigen Sie Ihre E-Mail-Adresse über den Link in der Bestätigungsmail und öffnen Sie danach diese Einladung erneut.';
+          return;
+        }
+        const resp = await fetch('/dpv/invitations/' + encodeURIComponent(key) + '/accept', {
+          method: 'POST',
+          headers: { 'Content-Type': 'application/json', 'Authorization': 'Basic ' + btoa(unescape(encodeURIComponent(email + ':' + password))) },
+          body: JSON.stringify({ token })
+        });
+        if (resp.ok) {
+          resultDiv.textContent = accepted;
+        } else if (resp.status === 401) {
+          resultDiv.textContent = 'Fehler: Anmeldung fehlgeschlagen';
+        } else {
+          const data = await resp.json();
+          resultDiv.textContent = 'Fehler: ' + (data.message || 'Unbekannter Fehler');
+        }
+      };
+    </script>
+</body>
+</html>`, title, title, invited, html.EscapeString(invitation.Email), jsKey, jsToken, jsAccepted)
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	api.Success(w, r, []byte(page))
+}
@@ -6,6 +6,8 @@ import (
 	"dpv/dpv/src/repository/graph"
 	"dpv/dpv/src/repository/t"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/julienschmidt/httprouter"
 )
@@ -24,15 +26,23 @@ func (h *ClubHandler) List(w http.ResponseWriter, r *http.Request, _ httprouter.
 	limit, _ := api.ParseInt(r.URL.Query().Get("limit"))
 
 	var clubs []entities.Club
+	var total int
 	var err error
 
 	if isAdmin {
-		options := graph.ClubQueryOptions{
-			Skip:   skip,
-			Limit:  limit,
-			Status: status,
+		options, err := clubQueryOptions(r)
+		if err != nil {
+			api.Error(w, r, err, http.StatusBadRequest)
+			return
+		}
+		options.Skip = skip
+		options.Limit = limit
+		options.Status = status
+		clubs, total, err = h.Service.GetAllClubs(r.Context(), options)
+		if err != nil {
+			api.Error(w, r, err, http.StatusInternalServerError)
+			return
 		}
-		clubs, err = h.Service.GetAllClubs(r.Context(), options)
 	} else {
 		// Non-admins only see clubs they administer
 		clubs, err = h.Service.ListClubs(r.Context(), user.Key)
@@ -46,6 +56,7 @@ func (h *ClubHandler) List(w http.ResponseWriter, r *http.Request, _ httprouter.
 			}
 			clubs = filtered
 		}
+		total = len(clubs)
 	}
 
 	if err != nil {
@@ -58,5 +69,110 @@ func (h *ClubHandler) List(w http.ResponseWriter, r *http.Request, _ httprouter.
 		resp = append(resp, *FilteredResponse(&c))
 	}
 
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	w.Header().Set("Access-Control-Expose-Headers", "X-Total-Count")
 	api.SuccessJson(w, r, resp)
 }
+
+// clubQueryOptions reads the admin filters and sort order of the club list from the query string.
+func clubQueryOptions(r *http.Request) (graph.ClubQueryOptions, error) {
+	q := r.URL.Query()
+	options := graph.ClubQueryOptions{
+		Archived:  q.Get("archived") == "true",
+		Search:    q.Get("q"),
+		LegalForm: q.Get("legal_form"),
+		ParentKey: q.Get("parent_key"),
+		Sort:      q.Get("sort"),
+	}
+
+	flags := []struct {
+		name  string
+		value **bool
+	}{
+		{"statutes_ok", &options.StatutesOK},
+		{"registry_ok", &options.RegistryOK},
+		{"website_ok", &options.WebsiteOK},
+	}
+	for _, f := range flags {
+		if v := q.Get(f.name); v != "" {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return options, t.Errorf("invalid value %s for %s", v, f.name)
+			}
+			*f.value = &b
+		}
+	}
+
+	if v := q.Get("missing_census"); v != "" {
+		year, err := api.ParseInt(v)
+		if err != nil || year <= 0 {
+			return options, t.Errorf("invalid year %s", v)
+		}
+		options.MissingCensusYear = year
+	}
+
+	if v := q.Get("created_from"); v != "" {
+		d, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			return options, t.Errorf("invalid date %s, expected YYYY-MM-DD", v)
+		}
+		options.CreatedFrom = d
+	}
+	if v := q.Get("created_to"); v != "" {
+		d, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			return options, t.Errorf("invalid date %s, expected YYYY-MM-DD", v)
+		}
+		options.CreatedTo = d.AddDate(0, 0, 1) // Include the whole day
+	}
+	if !options.CreatedFrom.IsZero() && !options.CreatedTo.IsZero() && options.CreatedTo.Before(options.CreatedFrom) {
+		return options, t.Errorf("created_to must not be before created_from")
+	}
+
+	if _, ok := graph.ClubSortFields[options.Sort]; options.Sort != "" && !ok {
+		return options, t.Errorf("invalid sort field %s", options.Sort)
+	}
+	switch q.Get("order") {
+	case "", "asc":
+	case "desc":
+		options.Desc = true
+	default:
+		return options, t.Errorf("invalid sort order %s", q.Get("order"))
+	}
+	return options, nil
+}
+
+// Restore brings back an archived club (Admin only).
+func (h *ClubHandler) Restore(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	_, err := api.RequireGlobalAdmin(r, h.Service.DB)
+	if err != nil {
+		api.Error(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	err = h.Service.RestoreClub(r.Context(), ps.ByName("key"))
+	if err != nil {
+		api.Error(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	api.SuccessJson(w, r, map[string]string{"message": t.T(t.Errorf("club restored"), api.DetectLanguage(r))})
+}
+
+// Purge permanently deletes an archived club with its census data and documents (Admin only).
+func (h *ClubHandler) Purge(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	_, err := api.RequireGlobalAdmin(r, h.Service.DB)
+	if err != nil {
+		api.Error(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	force := r.URL.Query().Get("force") == "true"
+	err = h.Service.PurgeClub(r.Context(), ps.ByName("key"), force)
+	if err != nil {
+		api.Error(w, r, t.Errorf("could not purge club: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -2,6 +2,7 @@ package clubs
 
 import (
 	"dpv/dpv/src/api"
+	"dpv/dpv/src/domain/entities"
 	"net/http"
 	"strings"
 
@@ -31,16 +32,20 @@ func (h *ClubHandler) GetPaymentDetails(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
-	isAdmin := api.IsAdmin(*user)
+	// Admins and the treasurer see everything unmasked
+	isTreasurer, err := h.Service.HasBoardFunction(r.Context(), user, key, entities.FunctionKassenwart)
+	if err != nil {
+		api.Error(w, r, err, http.StatusInternalServerError)
+		return
+	}
 
 	response := PaymentDetailsResponse{}
 
-	if isAdmin {
-		// Admin sees everything unmasked
+	if isTreasurer {
 		response.IBAN = club.Membership.IBAN
 		response.SEPAMandateNumber = club.Membership.SEPAMandateNumber
 	} else {
-		// Non-admin (club owner) sees masked IBAN, no Mandatsreferenz
+		// Other board members see masked IBAN, no Mandatsreferenz
 		response.IBAN = maskIBAN(club.Membership.IBAN)
 		// SEPAMandateNumber is omitted (omitempty will exclude it)
 	}
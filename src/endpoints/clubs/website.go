@@ -0,0 +1,67 @@
+package clubs
+
+import (
+	"dpv/dpv/src/api"
+	"dpv/dpv/src/service/club"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// WebsiteVerificationResponse tells a club how to prove ownership of its website.
+type WebsiteVerificationResponse struct {
+	Website             string    `json:"website"`
+	Token               string    `json:"token"`
+	MetaTag             string    `json:"meta_tag"`
+	WellKnownURL        string    `json:"well_known_url,omitempty"`
+	WebsiteOK           bool      `json:"website_ok"`
+	WebsiteVerification time.Time `json:"website_verification"`
+}
+
+// GetWebsiteVerification returns the verification token of a club and where to publish it.
+func (h *ClubHandler) GetWebsiteVerification(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	user, err := api.GetUserFromContext(r)
+	if err != nil {
+		api.Error(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	c, err := h.Service.GetClub(r.Context(), ps.ByName("key"), user)
+	if err != nil {
+		api.Error(w, r, err, http.StatusForbidden)
+		return
+	}
+
+	token := h.Service.WebsiteToken(c.Key)
+	response := WebsiteVerificationResponse{
+		Website:             c.Website,
+		Token:               token,
+		MetaTag:             fmt.Sprintf(`<meta name="%s" content="%s">`, club.MetaTagName, token),
+		WebsiteOK:           c.WebsiteOK,
+		WebsiteVerification: c.WebsiteVerification,
+	}
+	if u, err := club.NormalizeWebsite(c.Website); c.Website != "" && err == nil {
+		response.WellKnownURL = u.Scheme + "://" + u.Host + club.WellKnownPath
+	}
+
+	api.SuccessJson(w, r, response)
+}
+
+// VerifyWebsite checks the club website for its verification token right away.
+func (h *ClubHandler) VerifyWebsite(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	user, err := api.GetUserFromContext(r)
+	if err != nil {
+		api.Error(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	c, err := h.Service.VerifyWebsite(r.Context(), ps.ByName("key"), user)
+	if err != nil {
+		api.Error(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	api.SuccessJson(w, r, FilteredResponse(c))
+}
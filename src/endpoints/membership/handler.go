@@ -0,0 +1,31 @@
+package membership
+
+import (
+	"dpv/dpv/src/api"
+	"dpv/dpv/src/service/membership"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type Handler struct {
+	Service *membership.Service
+}
+
+func NewHandler(service *membership.Service) *Handler {
+	return &Handler{
+		Service: service,
+	}
+}
+
+// Verify - public: returns the current membership status of the holder of a membership card
+func (h *Handler) Verify(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	result, err := h.Service.Verify(r.Context(), ps.ByName("token"))
+	if err != nil {
+		api.Error(w, r, err, http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	api.SuccessJson(w, r, result)
+}
@@ -0,0 +1,250 @@
+package qualifications
+
+import (
+	"dpv/dpv/src/api"
+	"dpv/dpv/src/domain/entities"
+	"dpv/dpv/src/repository/t"
+	"dpv/dpv/src/service/qualification"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type Handler struct {
+	Service *qualification.Service
+}
+
+func NewHandler(service *qualification.Service) *Handler {
+	return &Handler{
+		Service: service,
+	}
+}
+
+type qualificationRequest struct {
+	LicenceType    string `json:"licence_type"`
+	Number         string `json:"number"`
+	IssuingBody    string `json:"issuing_body"`
+	Issued         string `json:"issued"`  // YYYY-MM-DD
+	Expires        string `json:"expires"` // YYYY-MM-DD, empty if the licence does not expire
+	RefresherHours int    `json:"refresher_hours"`
+}
+
+// decodeQualification reads a qualification from the request body.
+func decodeQualification(r *http.Request) (*entities.Qualification, error) {
+	var req qualificationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, t.Errorf("invalid JSON body")
+	}
+	q := &entities.Qualification{
+		LicenceType:    req.LicenceType,
+		Number:         req.Number,
+		IssuingBody:    req.IssuingBody,
+		RefresherHours: req.RefresherHours,
+	}
+	if strings.TrimSpace(req.Issued) != "" {
+		issued, err := time.Parse(time.DateOnly, strings.TrimSpace(req.Issued))
+		if err != nil {
+			return nil, t.Errorf("invalid date %s, expected YYYY-MM-DD", req.Issued)
+		}
+		q.Issued = issued
+	}
+	if strings.TrimSpace(req.Expires) != "" {
+		expires, err := time.Parse(time.DateOnly, strings.TrimSpace(req.Expires))
+		if err != nil {
+			return nil, t.Errorf("invalid date %s, expected YYYY-MM-DD", req.Expires)
+		}
+		q.Expires = &expires
+	}
+	return q, nil
+}
+
+// List returns the qualifications of the current user.
+func (h *Handler) List(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	user, err := api.GetUserFromContext(r)
+	if err != nil {
+		api.Error(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	qualifications, err := h.Service.List(r.Context(), user)
+	if err != nil {
+		api.Error(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	api.SuccessJson(w, r, qualifications)
+}
+
+// Create records a qualification of the current user.
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	user, err := api.GetUserFromContext(r)
+	if err != nil {
+		api.Error(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	q, err := decodeQualification(r)
+	if err != nil {
+		api.Error(w, r, err, http.StatusBadRequest)
+		return
+	}
+	if err := h.Service.Create(r.Context(), user, q); err != nil {
+		api.Error(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	api.SuccessJson(w, r, q)
+}
+
+// Update changes a qualification of the current user, e.g. after a renewal.
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	user, err := api.GetUserFromContext(r)
+	if err != nil {
+		api.Error(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	changes, err := decodeQualification(r)
+	if err != nil {
+		api.Error(w, r, err, http.StatusBadRequest)
+		return
+	}
+	q, err := h.Service.Update(r.Context(), user, ps.ByName("key"), changes)
+	if err != nil {
+		api.Error(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	api.SuccessJson(w, r, q)
+}
+
+// Delete removes a qualification of the current user.
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	user, err := api.GetUserFromContext(r)
+	if err != nil {
+		api.Error(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.Service.Delete(r.Context(), user, ps.ByName("key")); err != nil {
+		api.Error(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UploadCertificate stores the certificate of a qualification of the current user.
+func (h *Handler) UploadCertificate(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	user, err := api.GetUserFromContext(r)
+	if err != nil {
+		api.Error(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	if err := r.ParseMultipartForm(10 << 20); err != nil { // 10MB limit
+		api.Error(w, r, t.Errorf("parse multipart form failed: %w", err), http.StatusBadRequest)
+		return
+	}
+	file, header, err := r.FormFile("certificate")
+	if err != nil {
+		api.Error(w, r, t.Errorf("get certificate from form failed: %w", err), http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	q, err := h.Service.UploadCertificate(r.Context(), user, ps.ByName("key"), header.Filename, file)
+	if err != nil {
+		api.Error(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	api.SuccessJson(w, r, q)
+}
+
+// GetCertificate serves the certificate of a qualification to its holder or an admin.
+func (h *Handler) GetCertificate(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	user, err := api.GetUserFromContext(r)
+	if err != nil {
+		api.Error(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	path, err := h.Service.CertificatePath(r.Context(), user, ps.ByName("key"))
+	if err != nil {
+		api.Error(w, r, err, http.StatusNotFound)
+		return
+	}
+
+	http.ServeFile(w, r, path)
+}
+
+// ListByStatus lists qualifications by review status, "pending" by default (Admin only).
+func (h *Handler) ListByStatus(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	if _, err := api.RequireGlobalAdmin(r, h.Service.DB); err != nil {
+		api.Error(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	status := r.URL.Query().Get("status")
+	if status == "" {
+		status = "pending"
+	}
+	holders, err := h.Service.ListByStatus(r.Context(), status)
+	if err != nil {
+		api.Error(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	type entry struct {
+		entities.Qualification
+		UserKey   string `json:"user_key"`
+		FirstName string `json:"firstname"`
+		LastName  string `json:"lastname"`
+		Email     string `json:"email"`
+	}
+	resp := make([]entry, 0, len(holders))
+	for _, hq := range holders {
+		resp = append(resp, entry{hq.Qualification, hq.User.Key, hq.User.FirstName, hq.User.LastName, hq.User.Email})
+	}
+	api.SuccessJson(w, r, resp)
+}
+
+// Verify marks a qualification as verified (Admin only).
+func (h *Handler) Verify(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	h.review(w, r, ps, true)
+}
+
+// Reject marks a qualification as rejected (Admin only).
+func (h *Handler) Reject(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	h.review(w, r, ps, false)
+}
+
+func (h *Handler) review(w http.ResponseWriter, r *http.Request, ps httprouter.Params, verified bool) {
+	admin, err := api.RequireGlobalAdmin(r, h.Service.DB)
+	if err != nil {
+		api.Error(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		Comment string `json:"comment"`
+	}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			api.Error(w, r, t.Errorf("invalid JSON body"), http.StatusBadRequest)
+			return
+		}
+	}
+
+	q, err := h.Service.Review(r.Context(), ps.ByName("key"), verified, strings.TrimSpace(req.Comment), admin)
+	if err != nil {
+		api.Error(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	api.SuccessJson(w, r, q)
+}
@@ -0,0 +1,156 @@
+package users
+
+import (
+	"dpv/dpv/src/api"
+	"dpv/dpv/src/domain/entities"
+	"dpv/dpv/src/repository/t"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// ApplyMembership handles an individual membership application of the current user.
+func (h *UserHandler) ApplyMembership(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	userEntity, err := api.GetUserFromContext(r)
+	if err != nil {
+		api.Error(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.Service.ApplyMembership(r.Context(), userEntity); err != nil {
+		api.Error(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	api.SuccessJson(w, r, map[string]string{"message": t.T(t.Errorf("application submitted"), api.DetectLanguage(r))})
+}
+
+// CancelMembership handles the cancellation of the current user's individual membership.
+func (h *UserHandler) CancelMembership(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	userEntity, err := api.GetUserFromContext(r)
+	if err != nil {
+		api.Error(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.Service.CancelMembership(r.Context(), userEntity); err != nil {
+		api.Error(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	api.SuccessJson(w, r, map[string]string{"message": t.T(t.Errorf("membership cancelled/reset"), api.DetectLanguage(r))})
+}
+
+// UpdatePaymentDetails sets IBAN and SEPA mandate number of the current user's individual membership.
+func (h *UserHandler) UpdatePaymentDetails(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	userEntity, err := api.GetUserFromContext(r)
+	if err != nil {
+		api.Error(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		IBAN              *string `json:"iban"`
+		SEPAMandateNumber *string `json:"sepa_mandate_number"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.Error(w, r, t.Errorf("invalid JSON body"), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Service.UpdatePaymentDetails(r.Context(), userEntity, req.IBAN, req.SEPAMandateNumber); err != nil {
+		api.Error(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	api.SuccessJson(w, r, filteredResponse(userEntity))
+}
+
+// ApproveMembership handles individual membership approval (Admin only).
+func (h *UserHandler) ApproveMembership(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	_, err := api.RequireGlobalAdmin(r, h.Service.DB)
+	if err != nil {
+		api.Error(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.Service.ApproveMembership(r.Context(), ps.ByName("key")); err != nil {
+		api.Error(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	api.SuccessJson(w, r, map[string]string{"message": t.T(t.Errorf("membership approved"), api.DetectLanguage(r))})
+}
+
+// DenyMembership handles individual membership denial (Admin only).
+func (h *UserHandler) DenyMembership(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	_, err := api.RequireGlobalAdmin(r, h.Service.DB)
+	if err != nil {
+		api.Error(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.Service.DenyMembership(r.Context(), ps.ByName("key")); err != nil {
+		api.Error(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	api.SuccessJson(w, r, map[string]string{"message": t.T(t.Errorf("membership denied"), api.DetectLanguage(r))})
+}
+
+// ListMembers lists users by individual membership status, "requested" by default (Admin only).
+func (h *UserHandler) ListMembers(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	_, err := api.RequireGlobalAdmin(r, h.Service.DB)
+	if err != nil {
+		api.Error(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	status := r.URL.Query().Get("status")
+	if status == "" {
+		status = "requested"
+	}
+	users, err := h.Service.GetUsersByMembershipStatus(r.Context(), status)
+	if err != nil {
+		api.Error(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]*entities.User, 0, len(users))
+	for i := range users {
+		resp = append(resp, filteredResponse(&users[i]))
+	}
+	api.SuccessJson(w, r, resp)
+}
+
+// MembershipCard returns the card of the current user's active individual membership as PDF,
+// or as PNG with ?format=png.
+func (h *UserHandler) MembershipCard(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	userEntity, err := api.GetUserFromContext(r)
+	if err != nil {
+		api.Error(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	card, err := h.Service.Card(userEntity)
+	if err != nil {
+		api.Error(w, r, err, http.StatusForbidden)
+		return
+	}
+	format := strings.ToLower(r.URL.Query().Get("format"))
+	if format == "" {
+		format = "pdf"
+	}
+	content, contentType, err := card.Render(format)
+	if err != nil {
+		api.Error(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s-mitgliedsausweis.%s\"", api.SanitizeFilename(card.Name), format))
+	api.Success(w, r, content)
+}
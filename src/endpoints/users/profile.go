@@ -0,0 +1,68 @@
+package users
+
+import (
+	"dpv/dpv/src/api"
+	"dpv/dpv/src/domain/entities"
+	"dpv/dpv/src/repository/t"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// UpdateProfile sets the profile type and type-specific attributes of the current user.
+func (h *UserHandler) UpdateProfile(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	userEntity, err := api.GetUserFromContext(r)
+	if err != nil {
+		api.Error(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		Type    string           `json:"type"`
+		Profile entities.Profile `json:"profile"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.Error(w, r, t.Errorf("invalid JSON body"), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Service.UpdateProfile(r.Context(), userEntity, req.Type, req.Profile); err != nil {
+		api.Error(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	api.SuccessJson(w, r, filteredResponse(userEntity))
+}
+
+// ProfileTypes lists the configured user types with their type-specific attributes.
+func (h *UserHandler) ProfileTypes(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	api.SuccessJson(w, r, h.Service.ProfileTypes())
+}
+
+// ListUsers lists a page of the users of a profile type (Admin only).
+func (h *UserHandler) ListUsers(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	_, err := api.RequireGlobalAdmin(r, h.Service.DB)
+	if err != nil {
+		api.Error(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	query := r.URL.Query()
+	skip, _ := api.ParseInt(query.Get("skip"))
+	limit, _ := api.ParseInt(query.Get("limit"))
+	users, total, err := h.Service.GetUsersByType(r.Context(), query.Get("type"), skip, limit)
+	if err != nil {
+		api.Error(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	resp := make([]*entities.User, 0, len(users))
+	for i := range users {
+		resp = append(resp, filteredResponse(&users[i]))
+	}
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	w.Header().Set("Access-Control-Expose-Headers", "X-Total-Count")
+	api.SuccessJson(w, r, resp)
+}
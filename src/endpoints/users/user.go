@@ -47,6 +47,7 @@ func (h *UserHandler) Register(w http.ResponseWriter, r *http.Request, _ httprou
 		LastName:  req.LastName,
 		FirstName: req.FirstName,
 		Roles:     []string{"user"},
+		Type:      "user",
 	}
 
 	err := h.Service.CreateUser(context.Background(), userEntity, req.Password)
@@ -370,6 +371,8 @@ func filteredResponse(userEntity *entities.User) *entities.User {
 		Roles:      userEntity.Roles,
 		Membership: userEntity.Membership,
 		Language:   userEntity.Language,
+		Type:       userEntity.Type,
+		Profile:    userEntity.Profile,
 	}
 	return resp
 }
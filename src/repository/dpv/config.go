@@ -44,10 +44,15 @@ type Config struct {
 		DocumentPath string `yaml:"document_path"`
 	} `yaml:"storage"`
 	Settings struct {
-		Version            string   `yaml:"version"`
-		UserTypes          []string `yaml:"user_types"`
-		BaseURL            string   `yaml:"base_url"`
-		SupportedLanguages []string `yaml:"supported_languages"`
+		Version              string   `yaml:"version"`
+		UserTypes            []string `yaml:"user_types"`
+		BaseURL              string   `yaml:"base_url"`
+		SupportedLanguages   []string `yaml:"supported_languages"`
+		WebsiteCheckHours    int      `yaml:"website_check_hours"`
+		ReminderCheckHours   int      `yaml:"reminder_check_hours"`
+		CensusAgeBrackets    []int    `yaml:"census_age_brackets"`
+		CensusSportCode      string   `yaml:"census_sport_code"`
+		CensusSwingThreshold float64  `yaml:"census_swing_threshold"`
 	} `yaml:"settings"`
 	Path string
 }
@@ -69,6 +74,26 @@ func NewConfig(configPath string) (*Config, error) {
 	if err := d.Decode(&config); err != nil {
 		return nil, fmt.Errorf("could not decode config file: %w", err)
 	}
+	if err := validateAgeBrackets(config.Settings.CensusAgeBrackets); err != nil {
+		return nil, fmt.Errorf("invalid census_age_brackets: %w", err)
+	}
 	config.Path = configPath[:len(configPath)-len("config.yml")]
 	return config, nil
 }
+
+// validateAgeBrackets checks that age bracket lower bounds start at 0 and are strictly ascending.
+// No brackets are valid, the census then uses its default ones.
+func validateAgeBrackets(bounds []int) error {
+	if len(bounds) == 0 {
+		return nil
+	}
+	if bounds[0] != 0 {
+		return fmt.Errorf("the first bound must be 0, got %d", bounds[0])
+	}
+	for i := 1; i < len(bounds); i++ {
+		if bounds[i] <= bounds[i-1] {
+			return fmt.Errorf("bounds must be strictly ascending, got %d after %d", bounds[i], bounds[i-1])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package dpv
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidateAgeBrackets(t *testing.T) {
+	for _, bounds := range [][]int{nil, {0}, {0, 7, 15, 19, 27, 41, 61}} {
+		if err := validateAgeBrackets(bounds); err != nil {
+			t.Errorf("validateAgeBrackets(%v) = %v", bounds, err)
+		}
+	}
+	for _, bounds := range [][]int{{7, 15}, {0, 15, 7}, {0, 7, 7}, {-1, 7}} {
+		if err := validateAgeBrackets(bounds); err == nil {
+			t.Errorf("validateAgeBrackets(%v) = nil, want an error", bounds)
+		}
+	}
+}
+
+func TestNewConfigRejectsInvalidAgeBrackets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	if err := os.WriteFile(path, []byte("settings:\n  census_age_brackets: [0, 15, 7]\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewConfig(path); err == nil {
+		t.Error("NewConfig() accepted descending census_age_brackets")
+	}
+}
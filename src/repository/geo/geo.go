@@ -0,0 +1,64 @@
+package geo
+
+import (
+	"bufio"
+	_ "embed"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//go:embed postcodes.csv
+var postcodesCSV string
+
+var (
+	postcodes     map[string][2]float64
+	postcodesOnce sync.Once
+	postcodeRegex = regexp.MustCompile(`\b\d{5}\b`)
+)
+
+// ExtractPostcode returns the first five-digit postcode found in a free-text address.
+func ExtractPostcode(address string) string {
+	return postcodeRegex.FindString(address)
+}
+
+// Lookup returns the centroid of a postcode from the bundled table, using the longest known prefix.
+func Lookup(postcode string) (lat, lon float64, ok bool) {
+	postcodesOnce.Do(loadPostcodes)
+	for n := len(postcode); n >= 2; n-- {
+		if c, found := postcodes[postcode[:n]]; found {
+			return c[0], c[1], true
+		}
+	}
+	return 0, 0, false
+}
+
+// ValidCoordinates reports whether lat and lon are within the WGS 84 range.
+func ValidCoordinates(lat, lon float64) bool {
+	return lat >= -90 && lat <= 90 && lon >= -180 && lon <= 180
+}
+
+func loadPostcodes() {
+	postcodes = make(map[string][2]float64)
+	scanner := bufio.NewScanner(strings.NewReader(postcodesCSV))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Split(line, ",")
+		if len(fields) != 3 {
+			continue
+		}
+		lat, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			continue
+		}
+		lon, err := strconv.ParseFloat(fields[2], 64)
+		if err != nil {
+			continue
+		}
+		postcodes[fields[0]] = [2]float64{lat, lon}
+	}
+}
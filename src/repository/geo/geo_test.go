@@ -0,0 +1,66 @@
+package geo
+
+import "testing"
+
+func TestExtractPostcode(t *testing.T) {
+	tests := map[string]string{
+		"Musterstraße 1, 12345 Musterstadt": "12345",
+		"Hauptstr. 10\n80331 München":       "80331",
+		"no postcode here":                  "",
+		"Tel. 0301234567":                   "",
+	}
+	for address, expected := range tests {
+		if got := ExtractPostcode(address); got != expected {
+			t.Errorf("ExtractPostcode(%q) = %q, expected %q", address, got, expected)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	lat, lon, ok := Lookup("80331")
+	if !ok {
+		t.Fatal("expected postcode 80331 to be found")
+	}
+	if lat < 47 || lat > 49 || lon < 11 || lon > 12 {
+		t.Errorf("unexpected coordinates for Munich: %f, %f", lat, lon)
+	}
+
+	if _, _, ok := Lookup("00000"); ok {
+		t.Error("expected unknown postcode region to fail")
+	}
+	if _, _, ok := Lookup(""); ok {
+		t.Error("expected empty postcode to fail")
+	}
+}
+
+func TestValidCoordinates(t *testing.T) {
+	if !ValidCoordinates(52.52, 13.40) {
+		t.Error("Berlin should be valid")
+	}
+	if ValidCoordinates(91, 0) || ValidCoordinates(0, 181) {
+		t.Error("out of range coordinates should be invalid")
+	}
+}
+
+func TestFederalState(t *testing.T) {
+	tests := map[string]string{
+		"80331": "BY",
+		"10115": "BE",
+		"60311": "HE",
+		"63739": "BY",
+		"01067": "SN",
+		"":      "",
+		"1":     "",
+		"00000": "",
+	}
+	for postcode, want := range tests {
+		if got := FederalState(postcode); got != want {
+			t.Errorf("FederalState(%q) = %q, want %q", postcode, got, want)
+		}
+	}
+	for _, state := range postcodeStates {
+		if _, ok := FederalStates[state]; !ok {
+			t.Errorf("postcode region mapped to unknown state %s", state)
+		}
+	}
+}
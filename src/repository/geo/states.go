@@ -0,0 +1,46 @@
+package geo
+
+// FederalStates maps the ISO 3166-2 subdivision codes of the German federal states (without "DE-") to their names.
+var FederalStates = map[string]string{
+	"BW": "Baden-Württemberg",
+	"BY": "Bayern",
+	"BE": "Berlin",
+	"BB": "Brandenburg",
+	"HB": "Bremen",
+	"HH": "Hamburg",
+	"HE": "Hessen",
+	"MV": "Mecklenburg-Vorpommern",
+	"NI": "Niedersachsen",
+	"NW": "Nordrhein-Westfalen",
+	"RP": "Rheinland-Pfalz",
+	"SL": "Saarland",
+	"SN": "Sachsen",
+	"ST": "Sachsen-Anhalt",
+	"SH": "Schleswig-Holstein",
+	"TH": "Thüringen",
+}
+
+// postcodeStates assigns postcode regions to the federal state covering most of them. Regions crossing
+// state borders are refined by longer prefixes where known; clubs can also set their state explicitly.
+var postcodeStates = map[string]string{
+	"01": "SN", "02": "SN", "03": "BB", "04": "SN", "06": "ST", "07": "TH", "08": "SN", "09": "SN",
+	"10": "BE", "12": "BE", "13": "BE", "14": "BB", "15": "BB", "16": "BB", "17": "MV", "18": "MV", "19": "MV",
+	"20": "HH", "21": "NI", "22": "HH", "23": "SH", "24": "SH", "25": "SH", "26": "NI", "27": "NI", "28": "HB", "29": "NI",
+	"30": "NI", "31": "NI", "32": "NW", "33": "NW", "34": "HE", "35": "HE", "36": "HE", "37": "NI", "38": "NI", "39": "ST",
+	"40": "NW", "41": "NW", "42": "NW", "44": "NW", "45": "NW", "46": "NW", "47": "NW", "48": "NW", "49": "NI",
+	"50": "NW", "51": "NW", "52": "NW", "53": "NW", "54": "RP", "55": "RP", "56": "RP", "57": "NW", "58": "NW", "59": "NW",
+	"60": "HE", "61": "HE", "63": "HE", "637": "BY", "638": "BY", "639": "BY", "64": "HE", "65": "HE", "66": "SL", "67": "RP", "68": "BW", "69": "BW",
+	"70": "BW", "71": "BW", "72": "BW", "73": "BW", "74": "BW", "75": "BW", "76": "BW", "77": "BW", "78": "BW", "79": "BW",
+	"80": "BY", "81": "BY", "82": "BY", "83": "BY", "84": "BY", "85": "BY", "86": "BY", "87": "BY", "88": "BW", "89": "BW",
+	"90": "BY", "91": "BY", "92": "BY", "93": "BY", "94": "BY", "95": "BY", "96": "BY", "97": "BY", "98": "TH", "99": "TH",
+}
+
+// FederalState returns the federal state of a postcode using the longest known prefix, or "" if unknown.
+func FederalState(postcode string) string {
+	for n := len(postcode); n >= 2; n-- {
+		if state, found := postcodeStates[postcode[:n]]; found {
+			return state
+		}
+	}
+	return ""
+}
@@ -5,8 +5,10 @@ import (
 	"dpv/dpv/src/repository/dpv"
 	"dpv/dpv/src/repository/security"
 	"dpv/dpv/src/repository/t"
+	"encoding/json"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 
 	"github.com/arangodb/go-driver/v2/arangodb"
@@ -101,7 +103,153 @@ func GetOrCreateCollection(db arangodb.Database, name string, edges bool) (arang
 	}
 }
 
-var fields map[string]arangodb.ArangoSearchElementProperties
+// ClubSearchView is the ArangoSearch view used for full-text club search.
+const ClubSearchView = "clubs_search"
+
+// SearchAnalyzer splits lower-cased, accent-free text into bigrams for NGRAM_MATCH.
+const SearchAnalyzer = "dpv_ngram"
+
+// searchTransliterations spell out umlauts on both sides of a search, so "Muenchen" finds "München"
+// and the other way round. Other diacritics are stripped by the analyzer.
+var searchTransliterations = map[string]string{"ä": "ae", "ö": "oe", "ü": "ue", "ß": "ss"}
+
+var searchReplacer = func() *strings.Replacer {
+	var pairs []string
+	for from, to := range searchTransliterations {
+		pairs = append(pairs, from, to)
+	}
+	return strings.NewReplacer(pairs...)
+}()
+
+// NormalizeSearch prepares a search string the way the club search attributes are stored.
+func NormalizeSearch(s string) string {
+	return searchReplacer.Replace(strings.ToLower(strings.TrimSpace(s)))
+}
+
+// clubSearchAttributes maps the attributes of the computed "search" object to the club attributes they are derived from.
+var clubSearchAttributes = map[string]string{
+	"name":           "name",
+	"contact_person": "contact_person",
+	"email":          "email",
+	"city":           "city",
+	"address":        "membership.address",
+}
+
+// searchFields are the club attributes indexed in the search view.
+var searchFields = arangodb.ArangoSearchFields{
+	"search": {Fields: arangodb.ArangoSearchFields{
+		"name":           {},
+		"contact_person": {},
+		"email":          {},
+		"city":           {},
+		"address":        {},
+	}},
+}
+
+// EnsureClubSearchValues lets the database keep a transliterated copy of the searchable attributes
+// in club.search on every write, and fills it in for clubs stored before.
+func EnsureClubSearchValues(db arangodb.Database, clubs arangodb.Collection) error {
+	ctx := context.Background()
+	mapping, err := json.Marshal(searchTransliterations)
+	if err != nil {
+		return t.Errorf("could not encode search transliterations: %w", err)
+	}
+	names := make([]string, 0, len(clubSearchAttributes))
+	for name := range clubSearchAttributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	values := make([]string, len(names))
+	for i, name := range names {
+		values[i] = fmt.Sprintf("%s: SUBSTITUTE(LOWER(@doc.%s), %s)", name, clubSearchAttributes[name], mapping)
+	}
+	computed := []arangodb.ComputedValue{{
+		Name:       "search",
+		Expression: "RETURN {" + strings.Join(values, ", ") + "}",
+		Overwrite:  true,
+	}}
+	if err := clubs.SetPropertiesV2(ctx, arangodb.SetCollectionPropertiesOptionsV2{ComputedValues: &computed}); err != nil {
+		return t.Errorf("could not set computed search values: %w", err)
+	}
+	cursor, err := db.Query(ctx, `FOR club IN clubs FILTER club.search == null UPDATE club WITH {} IN clubs`, nil)
+	if err != nil {
+		return t.Errorf("could not compute search values: %w", err)
+	}
+	return cursor.Close()
+}
+
+// EnsureClubSearchView creates the search analyzer and the club search view, or updates the view's links.
+func EnsureClubSearchView(db arangodb.Database) error {
+	ctx := context.Background()
+	accent := false
+	preserveOriginal := false
+	bigram := int64(2)
+	streamType := arangodb.ArangoSearchNGramStreamUTF8
+	_, _, err := db.EnsureCreatedAnalyzer(ctx, &arangodb.AnalyzerDefinition{
+		Name: SearchAnalyzer,
+		Type: arangodb.ArangoSearchAnalyzerTypePipeline,
+		Properties: arangodb.ArangoSearchAnalyzerProperties{
+			Pipeline: []arangodb.ArangoSearchAnalyzerPipeline{
+				{
+					Type: arangodb.ArangoSearchAnalyzerTypeNorm,
+					Properties: arangodb.ArangoSearchAnalyzerProperties{
+						Locale: "de",
+						Case:   arangodb.ArangoSearchCaseLower,
+						Accent: &accent,
+					},
+				},
+				{
+					Type: arangodb.ArangoSearchAnalyzerTypeNGram,
+					Properties: arangodb.ArangoSearchAnalyzerProperties{
+						Min:              &bigram,
+						Max:              &bigram,
+						PreserveOriginal: &preserveOriginal,
+						StreamType:       &streamType,
+					},
+				},
+			},
+		},
+		Features: []arangodb.ArangoSearchFeature{
+			arangodb.ArangoSearchFeatureFrequency,
+			arangodb.ArangoSearchFeatureNorm,
+			arangodb.ArangoSearchFeaturePosition,
+		},
+	})
+	if err != nil {
+		return t.Errorf("could not ensure search analyzer: %w", err)
+	}
+
+	properties := arangodb.ArangoSearchViewProperties{
+		Links: arangodb.ArangoSearchLinks{
+			"clubs": {
+				Analyzers: []string{SearchAnalyzer},
+				Fields:    searchFields,
+			},
+		},
+	}
+	exists, err := db.ViewExists(ctx, ClubSearchView)
+	if err != nil {
+		return t.Errorf("could not check if view exists: %w", err)
+	}
+	if !exists {
+		if _, err := db.CreateArangoSearchView(ctx, ClubSearchView, &properties); err != nil {
+			return t.Errorf("could not create search view: %w", err)
+		}
+		return nil
+	}
+	view, err := db.View(ctx, ClubSearchView)
+	if err != nil {
+		return t.Errorf("could not open search view: %w", err)
+	}
+	searchView, err := view.ArangoSearchView()
+	if err != nil {
+		return t.Errorf("could not open search view: %w", err)
+	}
+	if err := searchView.SetProperties(ctx, properties); err != nil {
+		return t.Errorf("could not update search view: %w", err)
+	}
+	return nil
+}
 
 func NewEntityManager[T Entity](db arangodb.Database, name string, edges bool, constructor func() T) (EntityManager[T], error) {
 	collection, err := GetOrCreateCollection(db, name, edges)
@@ -2,10 +2,12 @@ package graph
 
 import (
 	"context"
+	"dpv/dpv/src/domain/dtos"
 	"dpv/dpv/src/domain/entities"
 	"dpv/dpv/src/repository/t"
 
 	"github.com/arangodb/go-driver/v2/arangodb"
+	"github.com/arangodb/go-driver/v2/arangodb/shared"
 )
 
 // GetCensus returns the census for a club and year.
@@ -27,7 +29,7 @@ func (db *Db) GetCensus(ctx context.Context, clubKey string, year int) (*entitie
 	defer cursor.Close()
 
 	if !cursor.HasMore() {
-		return nil, t.Errorf("census not found")
+		return nil, t.NotFound(t.Errorf("census not found"))
 	}
 
 	var result entities.Census
@@ -83,3 +85,406 @@ func (db *Db) UpsertCensus(ctx context.Context, clubKey string, census *entities
 
 	return nil
 }
+
+// UpdateCensusIfStatus updates a census only while it is still in one of the given workflow states, so
+// concurrent submissions, reviews and uploads cannot overwrite each other. Censuses stored before the
+// workflow existed are accepted. It returns false if the census is no longer in one of the states.
+func (db *Db) UpdateCensusIfStatus(ctx context.Context, census *entities.Census, states []string) (bool, error) {
+	query := `
+		FOR c IN censuses
+			FILTER c._key == @key AND NOT_NULL(c.status, "accepted") IN @states
+			UPDATE c WITH @census IN censuses
+			RETURN NEW._key
+	`
+	census.MemberCount = len(census.Members)
+	bindVars := map[string]interface{}{
+		"key":    census.GetKey(),
+		"states": states,
+		"census": census,
+	}
+	cursor, err := db.Database.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return false, t.Errorf("failed to update census %s: %w", census.GetKey(), err)
+	}
+	defer cursor.Close()
+	return cursor.HasMore(), nil
+}
+
+// GetAllCensuses returns the censuses of all clubs and years.
+func (db *Db) GetAllCensuses(ctx context.Context) ([]entities.Census, error) {
+	cursor, err := db.Database.Query(ctx, "FOR c IN censuses RETURN c", nil)
+	if err != nil {
+		return nil, t.Errorf("query for censuses failed: %w", err)
+	}
+	defer cursor.Close()
+
+	var result []entities.Census
+	for {
+		var doc entities.Census
+		_, err := cursor.ReadDocument(ctx, &doc)
+		if shared.IsNoMoreDocuments(err) {
+			break
+		} else if err != nil {
+			return nil, t.Errorf("obtaining census document failed: %w", err)
+		}
+		result = append(result, doc)
+	}
+	return result, nil
+}
+
+// GetCensusDeadline returns the reporting deadline of a year.
+func (db *Db) GetCensusDeadline(ctx context.Context, year int) (*entities.CensusDeadline, error) {
+	key := entities.CensusDeadlineKey(year)
+	exists, err := db.Deadlines.Has(key, ctx)
+	if err != nil {
+		return nil, t.Errorf("query for census deadline failed: %w", err)
+	}
+	if !exists {
+		return nil, t.NotFound(t.Errorf("census deadline not found"))
+	}
+	deadline, err := db.Deadlines.Read(key, ctx)
+	if err != nil {
+		return nil, t.Errorf("obtaining census deadline failed: %w", err)
+	}
+	return deadline, nil
+}
+
+// SaveCensusDeadline creates or updates the reporting deadline of a year.
+func (db *Db) SaveCensusDeadline(ctx context.Context, deadline *entities.CensusDeadline) error {
+	deadline.SetKey(entities.CensusDeadlineKey(deadline.Year))
+	exists, err := db.Deadlines.Has(deadline.GetKey(), ctx)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return db.Deadlines.Update(deadline, ctx)
+	}
+	return db.Deadlines.Create(deadline, ctx)
+}
+
+// GetCensusDeadlines returns all reporting deadlines, latest year first.
+func (db *Db) GetCensusDeadlines(ctx context.Context) ([]entities.CensusDeadline, error) {
+	query := `
+		FOR d IN census_deadlines
+			SORT d.year DESC
+			RETURN d
+	`
+	cursor, err := db.Database.Query(ctx, query, nil)
+	if err != nil {
+		return nil, t.Errorf("query for census deadlines failed: %w", err)
+	}
+	defer cursor.Close()
+
+	var result []entities.CensusDeadline
+	for {
+		var doc entities.CensusDeadline
+		_, err := cursor.ReadDocument(ctx, &doc)
+		if shared.IsNoMoreDocuments(err) {
+			break
+		} else if err != nil {
+			return nil, t.Errorf("obtaining census deadline failed: %w", err)
+		}
+		result = append(result, doc)
+	}
+	return result, nil
+}
+
+// CensusCount is the number of census members of an age bracket and gender.
+type CensusCount struct {
+	Bracket int    `json:"bracket"` // index into the age bracket bounds
+	Gender  string `json:"gender"`
+	Count   int    `json:"count"`
+}
+
+// CensusCounts aggregates the censuses of a year.
+type CensusCounts struct {
+	Clubs  int           `json:"clubs"`
+	Counts []CensusCount `json:"counts"`
+}
+
+// CountCensusMembers counts the members of the accepted censuses of a year by age bracket and gender, for
+// the given clubs or all clubs if clubKeys is nil. bounds are the ascending lower ages of the brackets.
+func (db *Db) CountCensusMembers(ctx context.Context, year int, clubKeys []string, bounds []int) (*CensusCounts, error) {
+	query := `
+		LET censuses = (
+			FOR e IN edges
+				FILTER e.type == "census" AND e.year == @year
+				FILTER @clubIds == null OR e._from IN @clubIds
+				LET census = DOCUMENT(e._to)
+				FILTER NOT_NULL(census.status, "accepted") == "accepted"
+				RETURN census
+		)
+		LET counts = (
+			FOR c IN censuses
+				FOR m IN NOT_NULL(c.members, [])
+					LET age = @year - m.birthYear
+					LET bracket = LAST(FOR i IN 0..LENGTH(@bounds)-1 FILTER age >= @bounds[i] RETURN i)
+					FILTER bracket != null
+					COLLECT b = bracket, g = m.gender WITH COUNT INTO n
+					RETURN { bracket: b, gender: g, count: n }
+		)
+		RETURN { clubs: LENGTH(censuses), counts: counts }
+	`
+	var clubIds []string
+	if clubKeys != nil {
+		clubIds = make([]string, 0, len(clubKeys))
+		for _, key := range clubKeys {
+			clubIds = append(clubIds, "clubs/"+key)
+		}
+	}
+	bindVars := map[string]interface{}{
+		"year":    year,
+		"clubIds": clubIds,
+		"bounds":  bounds,
+	}
+	cursor, err := db.Database.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return nil, t.Errorf("query for census statistics failed: %w", err)
+	}
+	defer cursor.Close()
+
+	var result CensusCounts
+	if _, err := cursor.ReadDocument(ctx, &result); err != nil {
+		return nil, t.Errorf("obtaining census statistics failed: %w", err)
+	}
+	return &result, nil
+}
+
+// GetClubSubtree returns the keys of a club and all clubs below it, following parent_key.
+// An unknown root club is a NotFound error.
+func (db *Db) GetClubSubtree(ctx context.Context, rootKey string) ([]string, error) {
+	exists, err := db.Clubs.Has(rootKey, ctx)
+	if err != nil {
+		return nil, t.Errorf("checking club failed: %w", err)
+	}
+	if !exists {
+		return nil, t.NotFound(t.Errorf("club not found"))
+	}
+	query := `
+		FOR club IN clubs
+			FILTER club.parent_key IN @parents
+			RETURN club._key
+	`
+	keys := []string{rootKey}
+	seen := map[string]bool{rootKey: true}
+	parents := []string{rootKey}
+	for len(parents) > 0 {
+		cursor, err := db.Database.Query(ctx, query, &arangodb.QueryOptions{BindVars: map[string]interface{}{"parents": parents}})
+		if err != nil {
+			return nil, t.Errorf("query for subsidiary clubs failed: %w", err)
+		}
+		var children []string
+		for {
+			var key string
+			_, err := cursor.ReadDocument(ctx, &key)
+			if shared.IsNoMoreDocuments(err) {
+				break
+			} else if err != nil {
+				cursor.Close()
+				return nil, t.Errorf("obtaining subsidiary club failed: %w", err)
+			}
+			// Guard against cycles in parent_key
+			if !seen[key] {
+				seen[key] = true
+				children = append(children, key)
+			}
+		}
+		cursor.Close()
+		keys = append(keys, children...)
+		parents = children
+	}
+	return keys, nil
+}
+
+// ClubCensusCounts are the census counts of one club.
+type ClubCensusCounts struct {
+	ClubKey      string        `json:"club_key"`
+	Name         string        `json:"name"`
+	FederalState string        `json:"federal_state"`
+	Address      string        `json:"address"`
+	Counts       []CensusCount `json:"counts"`
+}
+
+// CountCensusMembersByClub counts the members of the accepted censuses of a year per club by age bracket and gender, sorted by club name.
+func (db *Db) CountCensusMembersByClub(ctx context.Context, year int, bounds []int) ([]ClubCensusCounts, error) {
+	query := `
+		FOR e IN edges
+			FILTER e.type == "census" AND e.year == @year
+			LET census = DOCUMENT(e._to)
+			FILTER NOT_NULL(census.status, "accepted") == "accepted"
+			LET club = DOCUMENT(e._from)
+			LET counts = (
+				FOR m IN NOT_NULL(census.members, [])
+					LET age = @year - m.birthYear
+					LET bracket = LAST(FOR i IN 0..LENGTH(@bounds)-1 FILTER age >= @bounds[i] RETURN i)
+					FILTER bracket != null
+					COLLECT b = bracket, g = m.gender WITH COUNT INTO n
+					RETURN { bracket: b, gender: g, count: n }
+			)
+			SORT club.name
+			RETURN {
+				club_key: club._key,
+				name: club.name,
+				federal_state: club.federal_state,
+				address: club.membership.address,
+				counts: counts
+			}
+	`
+	bindVars := map[string]interface{}{
+		"year":   year,
+		"bounds": bounds,
+	}
+	cursor, err := db.Database.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return nil, t.Errorf("query for census statistics failed: %w", err)
+	}
+	defer cursor.Close()
+
+	var result []ClubCensusCounts
+	for {
+		var doc ClubCensusCounts
+		_, err := cursor.ReadDocument(ctx, &doc)
+		if shared.IsNoMoreDocuments(err) {
+			break
+		} else if err != nil {
+			return nil, t.Errorf("obtaining census statistics failed: %w", err)
+		}
+		result = append(result, doc)
+	}
+	return result, nil
+}
+
+// CensusMemberCountChange is the member count of a club's census and of its census in the previous year.
+type CensusMemberCountChange struct {
+	ClubKey     string `json:"club_key"`
+	Name        string `json:"name"`
+	MemberCount int    `json:"memberCount"`
+	BaseCount   *int   `json:"baseCount"` // nil without an accepted census in the previous year
+}
+
+// GetCensusMemberCountChanges returns the member counts of the submitted and accepted censuses of a year
+// together with the count of the club's accepted census of the previous year, sorted by club name.
+func (db *Db) GetCensusMemberCountChanges(ctx context.Context, year int) ([]CensusMemberCountChange, error) {
+	query := `
+		FOR e IN edges
+			FILTER e.type == "census" AND e.year == @year
+			LET census = DOCUMENT(e._to)
+			FILTER NOT_NULL(census.status, "accepted") IN ["submitted", "accepted"]
+			LET club = DOCUMENT(e._from)
+			LET base = FIRST(
+				FOR p IN edges
+					FILTER p.type == "census" AND p.year == @year - 1 AND p._from == e._from
+					LET previous = DOCUMENT(p._to)
+					FILTER NOT_NULL(previous.status, "accepted") == "accepted"
+					RETURN previous.memberCount
+			)
+			SORT club.name
+			RETURN {
+				club_key: club._key,
+				name: club.name,
+				memberCount: census.memberCount,
+				baseCount: base
+			}
+	`
+	cursor, err := db.Database.Query(ctx, query, &arangodb.QueryOptions{BindVars: map[string]interface{}{"year": year}})
+	if err != nil {
+		return nil, t.Errorf("query for census changes failed: %w", err)
+	}
+	defer cursor.Close()
+
+	var result []CensusMemberCountChange
+	for {
+		var doc CensusMemberCountChange
+		_, err := cursor.ReadDocument(ctx, &doc)
+		if shared.IsNoMoreDocuments(err) {
+			break
+		} else if err != nil {
+			return nil, t.Errorf("obtaining census changes failed: %w", err)
+		}
+		result = append(result, doc)
+	}
+	return result, nil
+}
+
+// CensusMember is a census member together with the club that reported it.
+type CensusMember struct {
+	ClubKey  string `json:"club_key"`
+	ClubName string `json:"club_name"`
+	entities.MemberRow
+}
+
+// GetCensusMembers returns the members of the submitted and accepted censuses of a year with their club,
+// sorted by club name.
+func (db *Db) GetCensusMembers(ctx context.Context, year int) ([]CensusMember, error) {
+	query := `
+		FOR e IN edges
+			FILTER e.type == "census" AND e.year == @year
+			LET census = DOCUMENT(e._to)
+			FILTER NOT_NULL(census.status, "accepted") IN ["submitted", "accepted"]
+			LET club = DOCUMENT(e._from)
+			SORT club.name
+			FOR m IN NOT_NULL(census.members, [])
+				RETURN MERGE(m, { club_key: club._key, club_name: club.name })
+	`
+	cursor, err := db.Database.Query(ctx, query, &arangodb.QueryOptions{BindVars: map[string]interface{}{"year": year}})
+	if err != nil {
+		return nil, t.Errorf("query for census members failed: %w", err)
+	}
+	defer cursor.Close()
+
+	var result []CensusMember
+	for {
+		var doc CensusMember
+		_, err := cursor.ReadDocument(ctx, &doc)
+		if shared.IsNoMoreDocuments(err) {
+			break
+		} else if err != nil {
+			return nil, t.Errorf("obtaining census member failed: %w", err)
+		}
+		result = append(result, doc)
+	}
+	return result, nil
+}
+
+// GetCensusStatuses returns the clubs whose census of a year is in a workflow state, sorted by club name.
+// Censuses stored before the workflow existed are accepted.
+func (db *Db) GetCensusStatuses(ctx context.Context, year int, status string) ([]dtos.CensusStatus, error) {
+	query := `
+		FOR e IN edges
+			FILTER e.type == "census" AND e.year == @year
+			LET census = DOCUMENT(e._to)
+			FILTER NOT_NULL(census.status, "accepted") == @status
+			LET club = DOCUMENT(e._from)
+			SORT club.name
+			RETURN {
+				club_key: club._key,
+				club_name: club.name,
+				year: @year,
+				status: @status,
+				memberCount: census.memberCount,
+				comment: census.comment
+			}
+	`
+	bindVars := map[string]interface{}{
+		"year":   year,
+		"status": status,
+	}
+	cursor, err := db.Database.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return nil, t.Errorf("query for census statuses failed: %w", err)
+	}
+	defer cursor.Close()
+
+	result := []dtos.CensusStatus{}
+	for {
+		var doc dtos.CensusStatus
+		_, err := cursor.ReadDocument(ctx, &doc)
+		if shared.IsNoMoreDocuments(err) {
+			break
+		} else if err != nil {
+			return nil, t.Errorf("obtaining census status failed: %w", err)
+		}
+		result = append(result, doc)
+	}
+	return result, nil
+}
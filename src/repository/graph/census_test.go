@@ -3,6 +3,7 @@ package graph
 import (
 	"context"
 	"dpv/dpv/src/domain/entities"
+	"dpv/dpv/src/repository/t"
 	"testing"
 )
 
@@ -79,3 +80,49 @@ func TestCensusRepository(t_test *testing.T) {
 		t_test.Errorf("expected updated club summary count 3, got %d", fetchedClub.Census[0].Count)
 	}
 }
+
+func TestGetCensusDeadlineNotFound(t_test *testing.T) {
+	db, _, err := Init("../../../config.yml", true)
+	if err != nil {
+		t_test.Fatalf("db initialisation failed: %s", err)
+	}
+	// CheckOpen only treats a missing deadline as open, other errors must be told apart
+	if _, err := db.GetCensusDeadline(context.Background(), 1900); !t.IsNotFound(err) {
+		t_test.Errorf("expected a not found error for a year without deadline, got %v", err)
+	}
+}
+
+func TestUpdateCensusIfStatus(t_test *testing.T) {
+	db, _, err := Init("../../../config.yml", true)
+	if err != nil {
+		t_test.Fatalf("db initialisation failed: %s", err)
+	}
+	ctx := context.Background()
+
+	club := &entities.Club{Name: "Test Club Census Workflow", LegalForm: "e.V."}
+	if err := db.Clubs.Create(club, ctx); err != nil {
+		t_test.Fatalf("Club creation failed: %s", err)
+	}
+	defer db.Clubs.Delete(club, ctx)
+	census := &entities.Census{Year: 2024, Status: entities.CensusDraft}
+	if err := db.UpsertCensus(ctx, club.GetKey(), census); err != nil {
+		t_test.Fatalf("UpsertCensus failed: %s", err)
+	}
+
+	census.Status = entities.CensusSubmitted
+	if updated, err := db.UpdateCensusIfStatus(ctx, census, []string{entities.CensusDraft}); err != nil || !updated {
+		t_test.Fatalf("UpdateCensusIfStatus() = %v, %v, want the draft submitted", updated, err)
+	}
+	// A second request that also read the draft must not overwrite the submission
+	census.Status = entities.CensusDraft
+	if updated, err := db.UpdateCensusIfStatus(ctx, census, []string{entities.CensusDraft}); err != nil || updated {
+		t_test.Errorf("UpdateCensusIfStatus() = %v, %v, want no update of a submitted census", updated, err)
+	}
+	fetched, err := db.GetCensus(ctx, club.GetKey(), 2024)
+	if err != nil {
+		t_test.Fatalf("GetCensus failed: %s", err)
+	}
+	if fetched.State() != entities.CensusSubmitted {
+		t_test.Errorf("expected a submitted census, got %q", fetched.State())
+	}
+}
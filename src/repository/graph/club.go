@@ -2,21 +2,49 @@ package graph
 
 import (
 	"context"
+	"dpv/dpv/src/domain/dtos"
 	"dpv/dpv/src/domain/entities"
 	"dpv/dpv/src/repository/t"
 
 	"math"
+	"time"
 
 	"github.com/arangodb/go-driver/v2/arangodb"
 	"github.com/arangodb/go-driver/v2/arangodb/shared"
 )
 
 type ClubQueryOptions struct {
-	Skip   int
-	Limit  int
-	Status string
+	Skip              int
+	Limit             int
+	Status            string
+	Archived          bool   // List archived clubs instead of current ones
+	Search            string // Fuzzy full-text search, results are ranked by relevance
+	LegalForm         string
+	ParentKey         string // Clubs of a Landesverband
+	StatutesOK        *bool
+	RegistryOK        *bool
+	WebsiteOK         *bool
+	MissingCensusYear int       // Clubs without a submitted or accepted census for this year
+	CreatedFrom       time.Time // Inclusive
+	CreatedTo         time.Time // Exclusive
+	Sort              string    // One of ClubSortFields, defaults to name or relevance
+	Desc              bool
 }
 
+// ClubSortFields maps the sort options of the club list to club attributes.
+var ClubSortFields = map[string]string{
+	"name":       "club.name",
+	"legal_form": "club.legal_form",
+	"city":       "club.city",
+	"status":     "club.membership.status",
+	"members":    "club.members",
+	"created":    "club.created",
+	"modified":   "club.modified",
+}
+
+// searchThreshold is the share of search bigrams a field has to contain to match.
+const searchThreshold = 0.5
+
 // CreateClub creates a club and an 'authorizes' edge for the creator.
 func (db *Db) CreateClub(ctx context.Context, club *entities.Club, userKey string) error {
 	// Create the club document
@@ -41,11 +69,14 @@ func (db *Db) CreateClub(ctx context.Context, club *entities.Club, userKey strin
 	return nil
 }
 
-// GetAdministeredClubs returns all clubs where the user is a board member.
+// GetAdministeredClubs returns all clubs where the user is a board member in office.
+// Like VorstandUser.InOffice, the term end is the last day in office.
 func (db *Db) GetAdministeredClubs(ctx context.Context, userKey string) ([]entities.Club, error) {
 	query := `
 		FOR v, e IN 1..1 OUTBOUND @userKey edges
-			FILTER e.type == "authorizes" AND e.role == "vorstand"
+			FILTER e.type == "authorizes" AND e.role == "vorstand" AND v.archived == null
+			FILTER e.term_start == null OR DATE_TIMESTAMP(e.term_start) <= DATE_NOW()
+			FILTER e.term_end == null OR DATE_NOW() < DATE_TIMESTAMP(DATE_ADD(e.term_end, 1, "day"))
 			RETURN v
 	`
 	bindVars := map[string]interface{}{
@@ -80,11 +111,14 @@ func (db *Db) GetClubByKey(ctx context.Context, key string) (*entities.Club, err
 		LET vorstand = (
 			FOR v, e IN 1..1 INBOUND CONCAT("clubs/", @key) edges
 				FILTER e.type == "authorizes" AND e.role == "vorstand"
-				RETURN {_key: v._key, firstname: v.firstname, lastname: v.lastname}
+				RETURN {
+					_key: v._key, firstname: v.firstname, lastname: v.lastname,
+					function: e.function, title: e.title, term_start: e.term_start, term_end: e.term_end
+				}
 		)
 		LET census = (
 			FOR v, e IN 1..1 OUTBOUND CONCAT("clubs/", @key) edges
-				FILTER e.type == "census"
+				FILTER e.type == "census" AND NOT_NULL(v.status, "accepted") == "accepted"
 				RETURN {year: e.year, count: v.memberCount}
 		)
 		RETURN MERGE(club, {vorstand: vorstand, census: census})
@@ -102,7 +136,7 @@ func (db *Db) GetClubByKey(ctx context.Context, key string) (*entities.Club, err
 	var result entities.Club
 	_, err = cursor.ReadDocument(ctx, &result)
 	if shared.IsNoMoreDocuments(err) {
-		return nil, t.Errorf("club not found")
+		return nil, t.NotFound(t.Errorf("club not found"))
 	} else if err != nil {
 		return nil, t.Errorf("obtaining club document failed: %w", err)
 	}
@@ -116,31 +150,46 @@ func (db *Db) UpdateClub(ctx context.Context, club *entities.Club) error {
 	return db.Clubs.Update(club, ctx)
 }
 
-// DeleteClub deletes a club.
-func (db *Db) DeleteClub(ctx context.Context, club *entities.Club) error {
-	query := `
-		FOR e IN edges
-			FILTER e._from == @id OR e._to == @id
-			REMOVE e IN edges
-	`
+// PurgeClub permanently deletes a club together with its census and document nodes,
+// its invitations and all its edges.
+func (db *Db) PurgeClub(ctx context.Context, club *entities.Club) error {
 	bindVars := map[string]interface{}{
 		"id": "clubs/" + club.GetKey(),
 	}
-
-	_, err := db.Database.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
-	if err != nil {
-		return t.Errorf("failed to remove club edges: %w", err)
+	queries := []struct{ query, what string }{
+		{`FOR e IN edges
+			FILTER e._from == @id AND e.type == "census"
+			REMOVE PARSE_IDENTIFIER(e._to).key IN censuses OPTIONS { ignoreErrors: true }`, "census"},
+		{`FOR e IN edges
+			FILTER e._from == @id AND e.type == "document"
+			REMOVE PARSE_IDENTIFIER(e._to).key IN documents OPTIONS { ignoreErrors: true }`, "documents"},
+		{`FOR i IN invitations
+			FILTER i.club_key == PARSE_IDENTIFIER(@id).key
+			REMOVE i IN invitations`, "invitations"},
+		{`FOR e IN edges
+			FILTER e._from == @id OR e._to == @id
+			REMOVE e IN edges`, "edges"},
+	}
+	for _, q := range queries {
+		cursor, err := db.Database.Query(ctx, q.query, &arangodb.QueryOptions{BindVars: bindVars})
+		if err != nil {
+			return t.Errorf("failed to remove club %s: %w", q.what, err)
+		}
+		cursor.Close()
 	}
 
 	return db.Clubs.Delete(club, ctx)
 }
 
-// GetClubs returns all clubs matching the options.
-func (db *Db) GetClubs(ctx context.Context, options ClubQueryOptions) ([]entities.Club, error) {
+// GetClubs returns the clubs matching the options and the total number of matches regardless of paging.
+func (db *Db) GetClubs(ctx context.Context, options ClubQueryOptions) ([]entities.Club, int, error) {
 	query, bindVars := buildClubQuery(options)
-	cursor, err := db.Database.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	cursor, err := db.Database.Query(ctx, query, &arangodb.QueryOptions{
+		BindVars: bindVars,
+		Options:  arangodb.QuerySubOptions{FullCount: true},
+	})
 	if err != nil {
-		return nil, t.Errorf("query for clubs failed: %w", err)
+		return nil, 0, t.Errorf("query for clubs failed: %w", err)
 	}
 	defer cursor.Close()
 
@@ -151,23 +200,89 @@ func (db *Db) GetClubs(ctx context.Context, options ClubQueryOptions) ([]entitie
 		if shared.IsNoMoreDocuments(err) {
 			break
 		} else if err != nil {
-			return nil, t.Errorf("obtaining club document failed: %w", err)
+			return nil, 0, t.Errorf("obtaining club document failed: %w", err)
 		}
 		result = append(result, doc)
 	}
 
-	return result, nil
+	total := int(cursor.Statistics().FullCountInt)
+	if _, paged := bindVars["limit"]; !paged {
+		total = len(result)
+	}
+	return result, total, nil
 }
 
 func buildClubQuery(options ClubQueryOptions) (string, map[string]interface{}) {
 	var query string
 	bindVars := map[string]interface{}{}
-	query += "FOR club IN clubs\n"
+	search := NormalizeSearch(options.Search)
+	if search != "" {
+		query += "FOR club IN " + ClubSearchView + "\n"
+		query += "  SEARCH NGRAM_MATCH(club.search.name, @search, @threshold, @analyzer)\n"
+		query += "    OR NGRAM_MATCH(club.search.contact_person, @search, @threshold, @analyzer)\n"
+		query += "    OR NGRAM_MATCH(club.search.email, @search, @threshold, @analyzer)\n"
+		query += "    OR NGRAM_MATCH(club.search.city, @search, @threshold, @analyzer)\n"
+		query += "    OR NGRAM_MATCH(club.search.address, @search, @threshold, @analyzer)\n"
+		bindVars["search"] = search
+		bindVars["threshold"] = searchThreshold
+		bindVars["analyzer"] = SearchAnalyzer
+	} else {
+		query += "FOR club IN clubs\n"
+	}
+	if options.Archived {
+		query += "  FILTER club.archived != null\n"
+	} else {
+		query += "  FILTER club.archived == null\n"
+	}
 	if options.Status != "" {
 		query += "  FILTER club.membership.status == @status\n"
 		bindVars["status"] = options.Status
 	}
-	query += "  SORT club.name\n"
+	if options.LegalForm != "" {
+		query += "  FILTER club.legal_form == @legalForm\n"
+		bindVars["legalForm"] = options.LegalForm
+	}
+	if options.ParentKey != "" {
+		query += "  FILTER club.parent_key == @parentKey\n"
+		bindVars["parentKey"] = options.ParentKey
+	}
+	flags := []struct {
+		attribute string
+		value     *bool
+	}{
+		{"statutes_ok", options.StatutesOK},
+		{"registry_ok", options.RegistryOK},
+		{"website_ok", options.WebsiteOK},
+	}
+	for _, f := range flags {
+		if f.value != nil {
+			query += "  FILTER (club." + f.attribute + " == true) == @" + f.attribute + "\n"
+			bindVars[f.attribute] = *f.value
+		}
+	}
+	if options.MissingCensusYear != 0 {
+		query += "  FILTER LENGTH(FOR v, e IN 1..1 OUTBOUND club edges FILTER e.type == \"census\" AND e.year == @censusYear AND NOT_NULL(v.status, \"accepted\") IN [\"submitted\", \"accepted\"] LIMIT 1 RETURN 1) == 0\n"
+		bindVars["censusYear"] = options.MissingCensusYear
+	}
+	if !options.CreatedFrom.IsZero() {
+		query += "  FILTER DATE_TIMESTAMP(club.created) >= @createdFrom\n"
+		bindVars["createdFrom"] = options.CreatedFrom.UnixMilli()
+	}
+	if !options.CreatedTo.IsZero() {
+		query += "  FILTER DATE_TIMESTAMP(club.created) < @createdTo\n"
+		bindVars["createdTo"] = options.CreatedTo.UnixMilli()
+	}
+	direction := "ASC"
+	if options.Desc {
+		direction = "DESC"
+	}
+	if field, ok := ClubSortFields[options.Sort]; ok {
+		query += "  SORT " + field + " " + direction + ", club.name\n"
+	} else if search != "" {
+		query += "  SORT BM25(club) DESC, club.name\n"
+	} else {
+		query += "  SORT club.name " + direction + "\n"
+	}
 	if options.Skip > 0 || options.Limit > 0 {
 		if options.Limit == 0 {
 			options.Limit = math.MaxInt32
@@ -219,6 +334,67 @@ func (db *Db) RemoveVorstand(ctx context.Context, clubKey, userKey string) error
 	return nil
 }
 
+// UpdateVorstand sets the board function and term of a board member.
+func (db *Db) UpdateVorstand(ctx context.Context, clubKey string, member entities.VorstandUser) error {
+	query := `
+		FOR e IN edges
+			FILTER e._from == @userKey AND e._to == @clubKey AND e.type == "authorizes" AND e.role == "vorstand"
+			UPDATE e WITH {function: @function, title: @title, term_start: @termStart, term_end: @termEnd} IN edges
+			RETURN NEW._key
+	`
+	bindVars := map[string]interface{}{
+		"userKey":   "users/" + member.Key,
+		"clubKey":   "clubs/" + clubKey,
+		"function":  member.Function,
+		"title":     member.Title,
+		"termStart": member.TermStart,
+		"termEnd":   member.TermEnd,
+	}
+	cursor, err := db.Database.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return t.Errorf("failed to update vorstand: %w", err)
+	}
+	defer cursor.Close()
+	if !cursor.HasMore() {
+		return t.Errorf("board member not found")
+	}
+	return nil
+}
+
+// GetVorstandByFunction returns the board members of a club holding a function, e.g. the treasurer.
+func (db *Db) GetVorstandByFunction(ctx context.Context, clubKey, function string) ([]entities.VorstandUser, error) {
+	query := `
+		FOR v, e IN 1..1 INBOUND CONCAT("clubs/", @key) edges
+			FILTER e.type == "authorizes" AND e.role == "vorstand" AND e.function == @function
+			RETURN {
+				_key: v._key, firstname: v.firstname, lastname: v.lastname,
+				function: e.function, title: e.title, term_start: e.term_start, term_end: e.term_end
+			}
+	`
+	bindVars := map[string]interface{}{
+		"key":      clubKey,
+		"function": function,
+	}
+	cursor, err := db.Database.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return nil, t.Errorf("query for board function failed: %w", err)
+	}
+	defer cursor.Close()
+
+	var result []entities.VorstandUser
+	for {
+		var doc entities.VorstandUser
+		_, err := cursor.ReadDocument(ctx, &doc)
+		if shared.IsNoMoreDocuments(err) {
+			break
+		} else if err != nil {
+			return nil, t.Errorf("obtaining board member failed: %w", err)
+		}
+		result = append(result, doc)
+	}
+	return result, nil
+}
+
 // CountVorstand returns the number of board members for a club.
 func (db *Db) CountVorstand(ctx context.Context, clubKey string) (int, error) {
 	query := `
@@ -244,3 +420,100 @@ func (db *Db) CountVorstand(ctx context.Context, clubKey string) (int, error) {
 	}
 	return count, nil
 }
+
+// UpdateClubWebsiteVerification stores the result of a website verification without writing the rest of the club.
+func (db *Db) UpdateClubWebsiteVerification(ctx context.Context, key string, ok bool, at time.Time) error {
+	return db.Clubs.UpdateFields(key, map[string]interface{}{"website_ok": ok, "website_verification": at}, ctx)
+}
+
+// GetClubsWithWebsite returns all clubs that have a website URL set.
+func (db *Db) GetClubsWithWebsite(ctx context.Context) ([]entities.Club, error) {
+	query := `
+		FOR club IN clubs
+			FILTER club.website != null AND club.website != "" AND club.archived == null
+			RETURN club
+	`
+	cursor, err := db.Database.Query(ctx, query, nil)
+	if err != nil {
+		return nil, t.Errorf("query for clubs with website failed: %w", err)
+	}
+	defer cursor.Close()
+
+	var result []entities.Club
+	for {
+		var doc entities.Club
+		_, err := cursor.ReadDocument(ctx, &doc)
+		if shared.IsNoMoreDocuments(err) {
+			break
+		} else if err != nil {
+			return nil, t.Errorf("obtaining club document failed: %w", err)
+		}
+		result = append(result, doc)
+	}
+
+	return result, nil
+}
+
+// publicClubProjection maps a club document to the fields of dtos.PublicClub.
+const publicClubProjection = `{
+				_key: club._key,
+				name: club.name,
+				city: club.city,
+				website: club.website,
+				email: club.public_email,
+				parent: club.parent_key ? DOCUMENT("clubs", club.parent_key).name : null,
+				location: club.location
+			}`
+
+// GetPublicClubs returns the directory entries of all active clubs that opted in to be listed publicly.
+func (db *Db) GetPublicClubs(ctx context.Context) ([]dtos.PublicClub, error) {
+	query := `
+		FOR club IN clubs
+			FILTER club.membership.status == "active" AND club.public_listing == true AND club.archived == null
+			SORT club.name
+			RETURN ` + publicClubProjection
+	return db.queryPublicClubs(ctx, query, nil)
+}
+
+// GetPublicClubsNear returns public directory entries within radius meters of a point, nearest first.
+// Clubs without a location are left out, GEO_DISTANCE is null for them.
+func (db *Db) GetPublicClubsNear(ctx context.Context, lat, lon, radius float64, limit int) ([]dtos.PublicClub, error) {
+	query := `
+		FOR club IN clubs
+			FILTER club.location != null
+			LET distance = GEO_DISTANCE(GEO_POINT(@lon, @lat), club.location)
+			FILTER distance != null AND distance <= @radius
+			FILTER club.membership.status == "active" AND club.public_listing == true AND club.archived == null
+			SORT distance
+			LIMIT @limit
+			RETURN MERGE(` + publicClubProjection + `, {distance: distance})`
+	bindVars := map[string]interface{}{
+		"lat":    lat,
+		"lon":    lon,
+		"radius": radius,
+		"limit":  limit,
+	}
+	return db.queryPublicClubs(ctx, query, bindVars)
+}
+
+func (db *Db) queryPublicClubs(ctx context.Context, query string, bindVars map[string]interface{}) ([]dtos.PublicClub, error) {
+	cursor, err := db.Database.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return nil, t.Errorf("query for public clubs failed: %w", err)
+	}
+	defer cursor.Close()
+
+	result := []dtos.PublicClub{}
+	for {
+		var doc dtos.PublicClub
+		_, err := cursor.ReadDocument(ctx, &doc)
+		if shared.IsNoMoreDocuments(err) {
+			break
+		} else if err != nil {
+			return nil, t.Errorf("obtaining public club failed: %w", err)
+		}
+		result = append(result, doc)
+	}
+
+	return result, nil
+}
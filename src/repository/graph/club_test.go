@@ -3,9 +3,73 @@ package graph
 import (
 	"context"
 	"dpv/dpv/src/domain/entities"
+	"strings"
 	"testing"
+	"time"
 )
 
+func TestBuildClubQuery(t *testing.T) {
+	query, bindVars := buildClubQuery(ClubQueryOptions{Status: "active", Limit: 10})
+	if !strings.HasPrefix(query, "FOR club IN clubs\n") || strings.Contains(query, "SEARCH") {
+		t.Errorf("query without search should scan the collection: %s", query)
+	}
+	if bindVars["status"] != "active" || bindVars["limit"] != 10 {
+		t.Errorf("unexpected bind vars: %v", bindVars)
+	}
+
+	query, bindVars = buildClubQuery(ClubQueryOptions{Search: " München ", Status: "active", Skip: 20, Limit: 10})
+	if !strings.HasPrefix(query, "FOR club IN "+ClubSearchView+"\n") {
+		t.Errorf("search should use the view: %s", query)
+	}
+	if bindVars["search"] != "muenchen" || bindVars["analyzer"] != SearchAnalyzer {
+		t.Errorf("unexpected bind vars: %v", bindVars)
+	}
+	search := strings.Index(query, "SEARCH")
+	filter := strings.Index(query, "FILTER club.membership.status")
+	sort := strings.Index(query, "SORT BM25(club) DESC")
+	limit := strings.Index(query, "LIMIT @skip, @limit")
+	if search < 0 || search > filter || filter > sort || sort > limit {
+		t.Errorf("search, status filter, relevance sort and paging should be combined in order: %s", query)
+	}
+}
+
+func TestBuildClubQueryFilters(t *testing.T) {
+	no := false
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	query, bindVars := buildClubQuery(ClubQueryOptions{
+		LegalForm:         "e.V.",
+		ParentKey:         "lv1",
+		StatutesOK:        &no,
+		MissingCensusYear: 2025,
+		CreatedFrom:       from,
+		Sort:              "created",
+		Desc:              true,
+	})
+	for _, part := range []string{
+		"FILTER club.legal_form == @legalForm",
+		"FILTER club.parent_key == @parentKey",
+		"FILTER (club.statutes_ok == true) == @statutes_ok",
+		"e.year == @censusYear",
+		"DATE_TIMESTAMP(club.created) >= @createdFrom",
+		"SORT club.created DESC, club.name",
+	} {
+		if !strings.Contains(query, part) {
+			t.Errorf("query should contain %q: %s", part, query)
+		}
+	}
+	if strings.Contains(query, "registry_ok") || strings.Contains(query, "@createdTo") {
+		t.Errorf("unset filters should not be applied: %s", query)
+	}
+	if bindVars["statutes_ok"] != false || bindVars["censusYear"] != 2025 || bindVars["createdFrom"] != from.UnixMilli() {
+		t.Errorf("unexpected bind vars: %v", bindVars)
+	}
+
+	query, _ = buildClubQuery(ClubQueryOptions{Search: "Parkour", Sort: "members"})
+	if !strings.Contains(query, "SORT club.members ASC, club.name") || strings.Contains(query, "BM25") {
+		t.Errorf("an explicit sort should replace the relevance ranking: %s", query)
+	}
+}
+
 func TestClubOwnerManagement(t *testing.T) {
 	db, _, err := Init("../../../config.yml", true)
 	if err != nil {
@@ -104,3 +168,167 @@ func TestClubOwnerManagement(t *testing.T) {
 	// db.Users.Delete(user2, ctx)
 	// Edges should be removed if we use DeleteClub, but users remain.
 }
+
+func TestUpdateClubClearsPublicFields(t *testing.T) {
+	db, _, err := Init("../../../config.yml", true)
+	if err != nil {
+		t.Fatalf("db initialisation failed: %s", err)
+	}
+	ctx := context.Background()
+
+	club := &entities.Club{
+		Name:          "Test Club Directory",
+		City:          "Leipzig",
+		FederalState:  "SN",
+		PublicEmail:   "kontakt@example.com",
+		PublicListing: true,
+		Membership:    entities.Membership{Status: "active"},
+	}
+	if err := db.Clubs.Create(club, ctx); err != nil {
+		t.Fatalf("Club creation failed: %s", err)
+	}
+	defer db.Clubs.Delete(club, ctx)
+
+	// Updates merge into the stored document, empty values must still overwrite
+	club.City = ""
+	club.FederalState = ""
+	club.PublicEmail = ""
+	if err := db.UpdateClub(ctx, club); err != nil {
+		t.Fatalf("UpdateClub failed: %v", err)
+	}
+	fetched, err := db.GetClubByKey(ctx, club.GetKey())
+	if err != nil {
+		t.Fatalf("GetClubByKey failed: %v", err)
+	}
+	if fetched.City != "" || fetched.FederalState != "" || fetched.PublicEmail != "" {
+		t.Errorf("cleared fields were kept: city %q, federal_state %q, public_email %q", fetched.City, fetched.FederalState, fetched.PublicEmail)
+	}
+}
+
+func TestGetAdministeredClubsSkipsEndedTerm(t *testing.T) {
+	db, _, err := Init("../../../config.yml", true)
+	if err != nil {
+		t.Fatalf("db initialisation failed: %s", err)
+	}
+	ctx := context.Background()
+
+	user := &entities.User{Email: "former_board@example.com", FirstName: "Former", LastName: "Board"}
+	if err := db.Users.Create(user, ctx); err != nil {
+		t.Fatalf("User creation failed: %s", err)
+	}
+	defer db.Users.Delete(user, ctx)
+	club := &entities.Club{Name: "Test Club Term", Membership: entities.Membership{Status: "active"}}
+	if err := db.CreateClub(ctx, club, user.GetKey()); err != nil {
+		t.Fatalf("Club creation failed: %s", err)
+	}
+	defer db.Clubs.Delete(club, ctx)
+
+	administers := func() bool {
+		clubs, err := db.GetAdministeredClubs(ctx, user.GetKey())
+		if err != nil {
+			t.Fatalf("GetAdministeredClubs failed: %v", err)
+		}
+		for _, c := range clubs {
+			if c.GetKey() == club.GetKey() {
+				return true
+			}
+		}
+		return false
+	}
+
+	today := time.Now().Truncate(24 * time.Hour)
+	yesterday := today.AddDate(0, 0, -1)
+	member := entities.VorstandUser{Key: user.GetKey(), TermEnd: &today}
+	if err := db.UpdateVorstand(ctx, club.GetKey(), member); err != nil {
+		t.Fatalf("UpdateVorstand failed: %v", err)
+	}
+	if !administers() {
+		t.Error("board member should be in office on the last day of the term")
+	}
+
+	member.TermEnd = &yesterday
+	if err := db.UpdateVorstand(ctx, club.GetKey(), member); err != nil {
+		t.Fatalf("UpdateVorstand failed: %v", err)
+	}
+	if administers() {
+		t.Error("board member whose term has ended should not administer the club")
+	}
+}
+
+func TestSearchClubsTransliteratesUmlauts(t *testing.T) {
+	db, _, err := Init("../../../config.yml", true)
+	if err != nil {
+		t.Fatalf("db initialisation failed: %s", err)
+	}
+	ctx := context.Background()
+
+	umlaut := &entities.Club{Name: "Parkour Gemeinschaft Süderbrück", City: "München"}
+	spelled := &entities.Club{Name: "Freerunning Groszkoeln", City: "Koeln"}
+	for _, club := range []*entities.Club{umlaut, spelled} {
+		if err := db.Clubs.Create(club, ctx); err != nil {
+			t.Fatalf("Club creation failed: %s", err)
+		}
+		defer db.Clubs.Delete(club, ctx)
+	}
+
+	// The search view is updated asynchronously
+	found := func(search string, club *entities.Club) bool {
+		for i := 0; i < 20; i++ {
+			clubs, _, err := db.GetClubs(ctx, ClubQueryOptions{Search: search})
+			if err != nil {
+				t.Fatalf("GetClubs failed: %v", err)
+			}
+			for _, c := range clubs {
+				if c.GetKey() == club.GetKey() {
+					return true
+				}
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+		return false
+	}
+	if !found("Muenchen", umlaut) {
+		t.Error("searching Muenchen should find a club in München")
+	}
+	if !found("Suederbrueck", umlaut) {
+		t.Error("searching Suederbrueck should find Süderbrück")
+	}
+	if !found("Köln", spelled) {
+		t.Error("searching Köln should find a club in Koeln")
+	}
+}
+
+func TestUpdateClubClearsPaymentDetails(t *testing.T) {
+	db, _, err := Init("../../../config.yml", true)
+	if err != nil {
+		t.Fatalf("db initialisation failed: %s", err)
+	}
+	ctx := context.Background()
+
+	club := &entities.Club{
+		Name: "Test Club Payment",
+		Membership: entities.Membership{
+			Status:            "active",
+			IBAN:              "DE89370400440532013000",
+			SEPAMandateNumber: "DPV-2024-001",
+		},
+	}
+	if err := db.Clubs.Create(club, ctx); err != nil {
+		t.Fatalf("Club creation failed: %s", err)
+	}
+	defer db.Clubs.Delete(club, ctx)
+
+	club.Membership.IBAN = ""
+	club.Membership.SEPAMandateNumber = ""
+	if err := db.UpdateClub(ctx, club); err != nil {
+		t.Fatalf("UpdateClub failed: %v", err)
+	}
+	fetched, err := db.GetClubByKey(ctx, club.GetKey())
+	if err != nil {
+		t.Fatalf("GetClubByKey failed: %v", err)
+	}
+	if fetched.Membership.IBAN != "" || fetched.Membership.SEPAMandateNumber != "" {
+		t.Errorf("cleared payment details were kept: iban %q, sepa_mandate_number %q",
+			fetched.Membership.IBAN, fetched.Membership.SEPAMandateNumber)
+	}
+}
@@ -52,6 +52,15 @@ func (im *EntityManager[T]) Update(item T, ctx context.Context) error {
 	return nil
 }
 
+// UpdateFields sets only the given fields of a document, leaving concurrent changes to other fields intact.
+func (im *EntityManager[T]) UpdateFields(key string, fields map[string]interface{}, ctx context.Context) error {
+	_, err := im.Collection.UpdateDocument(ctx, key, fields)
+	if err != nil {
+		return t.Errorf("could not update item with key %v: %w", key, err)
+	}
+	return nil
+}
+
 func (im *EntityManager[T]) Delete(item T, ctx context.Context) error {
 	_, err := im.Collection.DeleteDocument(ctx, item.GetKey())
 	if err != nil {
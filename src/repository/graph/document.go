@@ -0,0 +1,94 @@
+package graph
+
+import (
+	"context"
+	"dpv/dpv/src/domain/entities"
+	"dpv/dpv/src/repository/t"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+	"github.com/arangodb/go-driver/v2/arangodb/shared"
+)
+
+// CreateDocument stores the metadata of an uploaded file and links it to the club.
+func (db *Db) CreateDocument(ctx context.Context, clubKey string, doc *entities.Document) error {
+	if err := db.Documents.Create(doc, ctx); err != nil {
+		return t.Errorf("failed to create document node: %w", err)
+	}
+
+	edge := map[string]interface{}{
+		"_from": "clubs/" + clubKey,
+		"_to":   "documents/" + doc.GetKey(),
+		"type":  "document",
+	}
+	if _, err := db.Edges.CreateDocument(ctx, edge); err != nil {
+		return t.Errorf("failed to create document edge: %w", err)
+	}
+	return nil
+}
+
+// GetDocuments returns the metadata of all documents uploaded for a club.
+func (db *Db) GetDocuments(ctx context.Context, clubKey string) ([]entities.Document, error) {
+	query := `
+		FOR v, e IN 1..1 OUTBOUND @clubKey edges
+			FILTER e.type == "document"
+			RETURN v
+	`
+	bindVars := map[string]interface{}{
+		"clubKey": "clubs/" + clubKey,
+	}
+
+	cursor, err := db.Database.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return nil, t.Errorf("query for documents failed: %w", err)
+	}
+	defer cursor.Close()
+
+	var result []entities.Document
+	for {
+		var doc entities.Document
+		_, err := cursor.ReadDocument(ctx, &doc)
+		if shared.IsNoMoreDocuments(err) {
+			break
+		} else if err != nil {
+			return nil, t.Errorf("obtaining document failed: %w", err)
+		}
+		result = append(result, doc)
+	}
+
+	return result, nil
+}
+
+// GetDocument returns the metadata of a single club document by filename.
+func (db *Db) GetDocument(ctx context.Context, clubKey, filename string) (*entities.Document, error) {
+	query := `
+		FOR v, e IN 1..1 OUTBOUND @clubKey edges
+			FILTER e.type == "document" AND v.filename == @filename
+			LIMIT 1
+			RETURN v
+	`
+	bindVars := map[string]interface{}{
+		"clubKey":  "clubs/" + clubKey,
+		"filename": filename,
+	}
+
+	cursor, err := db.Database.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return nil, t.Errorf("query for document failed: %w", err)
+	}
+	defer cursor.Close()
+
+	var result entities.Document
+	_, err = cursor.ReadDocument(ctx, &result)
+	if shared.IsNoMoreDocuments(err) {
+		return nil, t.NotFound(t.Errorf("document not found"))
+	} else if err != nil {
+		return nil, t.Errorf("obtaining document failed: %w", err)
+	}
+
+	return &result, nil
+}
+
+// UpdateDocument updates the metadata of a club document.
+func (db *Db) UpdateDocument(ctx context.Context, doc *entities.Document) error {
+	return db.Documents.Update(doc, ctx)
+}
@@ -1,6 +1,7 @@
 package graph
 
 import (
+	"context"
 	"dpv/dpv/src/domain/entities"
 	"dpv/dpv/src/repository/dpv"
 	"dpv/dpv/src/repository/t"
@@ -9,11 +10,15 @@ import (
 )
 
 type Db struct {
-	Database arangodb.Database
-	Users    EntityManager[*entities.User]
-	Clubs    EntityManager[*entities.Club]
-	Edges    arangodb.Collection
-	Censuses EntityManager[*entities.Census]
+	Database       arangodb.Database
+	Users          EntityManager[*entities.User]
+	Clubs          EntityManager[*entities.Club]
+	Edges          arangodb.Collection
+	Censuses       EntityManager[*entities.Census]
+	Documents      EntityManager[*entities.Document]
+	Invitations    EntityManager[*entities.Invitation]
+	Deadlines      EntityManager[*entities.CensusDeadline]
+	Qualifications EntityManager[*entities.Qualification]
 }
 
 func NewDB(database arangodb.Database, config *dpv.Config) (*Db, error) {
@@ -25,6 +30,16 @@ func NewDB(database arangodb.Database, config *dpv.Config) (*Db, error) {
 	if err != nil {
 		return nil, err
 	}
+	geoJSON := true
+	if _, _, err := clubs.Collection.EnsureGeoIndex(context.Background(), []string{"location"}, &arangodb.CreateGeoIndexOptions{GeoJSON: &geoJSON}); err != nil {
+		return nil, t.Errorf("could not ensure geo index on clubs: %w", err)
+	}
+	if err := EnsureClubSearchValues(database, clubs.Collection); err != nil {
+		return nil, err
+	}
+	if err := EnsureClubSearchView(database); err != nil {
+		return nil, err
+	}
 	edges, err := GetOrCreateCollection(database, "edges", true)
 	if err != nil {
 		return nil, t.Errorf("could not get or create edges collection: %w", err)
@@ -33,11 +48,31 @@ func NewDB(database arangodb.Database, config *dpv.Config) (*Db, error) {
 	if err != nil {
 		return nil, err
 	}
+	documents, err := NewEntityManager[*entities.Document](database, "documents", false, func() *entities.Document { return new(entities.Document) })
+	if err != nil {
+		return nil, err
+	}
+	invitations, err := NewEntityManager[*entities.Invitation](database, "invitations", false, func() *entities.Invitation { return new(entities.Invitation) })
+	if err != nil {
+		return nil, err
+	}
+	deadlines, err := NewEntityManager[*entities.CensusDeadline](database, "census_deadlines", false, func() *entities.CensusDeadline { return new(entities.CensusDeadline) })
+	if err != nil {
+		return nil, err
+	}
+	qualifications, err := NewEntityManager[*entities.Qualification](database, "qualifications", false, func() *entities.Qualification { return new(entities.Qualification) })
+	if err != nil {
+		return nil, err
+	}
 	return &Db{
 		database,
 		users,
 		clubs,
 		edges,
 		censuses,
+		documents,
+		invitations,
+		deadlines,
+		qualifications,
 	}, nil
 }
@@ -0,0 +1,47 @@
+package graph
+
+import (
+	"context"
+	"dpv/dpv/src/domain/entities"
+	"dpv/dpv/src/repository/t"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+	"github.com/arangodb/go-driver/v2/arangodb/shared"
+)
+
+// GetPendingInvitations returns the pending invitations of a club, optionally restricted to one role and email address.
+func (db *Db) GetPendingInvitations(ctx context.Context, clubKey, role, email string) ([]entities.Invitation, error) {
+	query := `
+		FOR i IN invitations
+			FILTER i.club_key == @clubKey AND i.status == "pending"
+			FILTER @role == "" OR NOT_NULL(i.role, "vorstand") == @role
+			FILTER @email == "" OR i.email == @email
+			SORT i.expires DESC
+			RETURN i
+	`
+	bindVars := map[string]interface{}{
+		"clubKey": clubKey,
+		"role":    role,
+		"email":   email,
+	}
+
+	cursor, err := db.Database.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return nil, t.Errorf("query for invitations failed: %w", err)
+	}
+	defer cursor.Close()
+
+	result := []entities.Invitation{}
+	for {
+		var doc entities.Invitation
+		_, err := cursor.ReadDocument(ctx, &doc)
+		if shared.IsNoMoreDocuments(err) {
+			break
+		} else if err != nil {
+			return nil, t.Errorf("obtaining invitation failed: %w", err)
+		}
+		result = append(result, doc)
+	}
+
+	return result, nil
+}
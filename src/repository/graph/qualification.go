@@ -0,0 +1,243 @@
+package graph
+
+import (
+	"context"
+	"dpv/dpv/src/domain/entities"
+	"dpv/dpv/src/repository/t"
+	"time"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+	"github.com/arangodb/go-driver/v2/arangodb/shared"
+)
+
+// QualificationHolder is a qualification together with the user holding it.
+type QualificationHolder struct {
+	Qualification entities.Qualification `json:"qualification"`
+	User          entities.User          `json:"user"`
+}
+
+// Coach is a coach of a club together with all their qualifications.
+type Coach struct {
+	User           entities.User            `json:"user"`
+	Qualifications []entities.Qualification `json:"qualifications"`
+}
+
+// CreateQualification stores a qualification and links it to the user.
+func (db *Db) CreateQualification(ctx context.Context, userKey string, q *entities.Qualification) error {
+	if err := db.Qualifications.Create(q, ctx); err != nil {
+		return t.Errorf("failed to create qualification node: %w", err)
+	}
+
+	edge := map[string]interface{}{
+		"_from": "users/" + userKey,
+		"_to":   "qualifications/" + q.GetKey(),
+		"type":  "qualification",
+	}
+	if _, err := db.Edges.CreateDocument(ctx, edge); err != nil {
+		return t.Errorf("failed to create qualification edge: %w", err)
+	}
+	return nil
+}
+
+// GetQualifications returns all qualifications of a user, the latest expiry first.
+func (db *Db) GetQualifications(ctx context.Context, userKey string) ([]entities.Qualification, error) {
+	query := `
+		FOR v, e IN 1..1 OUTBOUND @userKey edges
+			FILTER e.type == "qualification"
+			SORT v.expires DESC
+			RETURN v
+	`
+	bindVars := map[string]interface{}{
+		"userKey": "users/" + userKey,
+	}
+
+	cursor, err := db.Database.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return nil, t.Errorf("query for qualifications failed: %w", err)
+	}
+	defer cursor.Close()
+
+	result := []entities.Qualification{}
+	for {
+		var doc entities.Qualification
+		_, err := cursor.ReadDocument(ctx, &doc)
+		if shared.IsNoMoreDocuments(err) {
+			break
+		} else if err != nil {
+			return nil, t.Errorf("obtaining qualification failed: %w", err)
+		}
+		result = append(result, doc)
+	}
+	return result, nil
+}
+
+// GetQualificationHolder returns a qualification and the user holding it.
+func (db *Db) GetQualificationHolder(ctx context.Context, key string) (*QualificationHolder, error) {
+	query := `
+		FOR q IN qualifications
+			FILTER q._key == @key
+			FOR u, e IN 1..1 INBOUND q edges
+				FILTER e.type == "qualification"
+				LIMIT 1
+				RETURN {qualification: q, user: u}
+	`
+	holders, err := db.queryQualificationHolders(ctx, query, map[string]interface{}{"key": key})
+	if err != nil {
+		return nil, err
+	}
+	if len(holders) == 0 {
+		return nil, t.Errorf("qualification not found")
+	}
+	return &holders[0], nil
+}
+
+// GetQualificationsByStatus returns all qualifications with the given review status, oldest first.
+func (db *Db) GetQualificationsByStatus(ctx context.Context, status string) ([]QualificationHolder, error) {
+	query := `
+		FOR q IN qualifications
+			FILTER q.status == @status
+			SORT q.created
+			FOR u, e IN 1..1 INBOUND q edges
+				FILTER e.type == "qualification"
+				RETURN {qualification: q, user: u}
+	`
+	return db.queryQualificationHolders(ctx, query, map[string]interface{}{"status": status})
+}
+
+// GetExpiringQualifications returns verified qualifications whose last valid day lies between from and until.
+func (db *Db) GetExpiringQualifications(ctx context.Context, from, until time.Time) ([]QualificationHolder, error) {
+	query := `
+		FOR q IN qualifications
+			FILTER q.status == "verified" AND q.expires != null AND q.expires >= @from AND q.expires <= @until
+			FOR u, e IN 1..1 INBOUND q edges
+				FILTER e.type == "qualification"
+				RETURN {qualification: q, user: u}
+	`
+	bindVars := map[string]interface{}{"from": from, "until": until}
+	return db.queryQualificationHolders(ctx, query, bindVars)
+}
+
+func (db *Db) queryQualificationHolders(ctx context.Context, query string, bindVars map[string]interface{}) ([]QualificationHolder, error) {
+	cursor, err := db.Database.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return nil, t.Errorf("query for qualifications failed: %w", err)
+	}
+	defer cursor.Close()
+
+	result := []QualificationHolder{}
+	for {
+		var doc QualificationHolder
+		_, err := cursor.ReadDocument(ctx, &doc)
+		if shared.IsNoMoreDocuments(err) {
+			break
+		} else if err != nil {
+			return nil, t.Errorf("obtaining qualification failed: %w", err)
+		}
+		result = append(result, doc)
+	}
+	return result, nil
+}
+
+// UpdateQualification updates a qualification.
+func (db *Db) UpdateQualification(ctx context.Context, q *entities.Qualification) error {
+	return db.Qualifications.Update(q, ctx)
+}
+
+// DeleteQualification removes a qualification and its edge.
+func (db *Db) DeleteQualification(ctx context.Context, q *entities.Qualification) error {
+	query := `
+		FOR e IN edges
+			FILTER e._to == @id AND e.type == "qualification"
+			REMOVE e IN edges
+	`
+	bindVars := map[string]interface{}{
+		"id": "qualifications/" + q.GetKey(),
+	}
+	cursor, err := db.Database.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return t.Errorf("failed to remove qualification edge: %w", err)
+	}
+	cursor.Close()
+
+	return db.Qualifications.Delete(q, ctx)
+}
+
+// AddCoach links a user to a club as coach.
+func (db *Db) AddCoach(ctx context.Context, clubKey, userKey string) error {
+	query := `
+		UPSERT { _from: @userKey, _to: @clubKey, type: "coaches" }
+		INSERT { _from: @userKey, _to: @clubKey, type: "coaches" }
+		UPDATE {} IN edges
+	`
+	bindVars := map[string]interface{}{
+		"userKey": "users/" + userKey,
+		"clubKey": "clubs/" + clubKey,
+	}
+	cursor, err := db.Database.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return t.Errorf("failed to add coach: %w", err)
+	}
+	cursor.Close()
+	return nil
+}
+
+// RemoveCoach removes a user from the coaches of a club.
+func (db *Db) RemoveCoach(ctx context.Context, clubKey, userKey string) error {
+	query := `
+		FOR e IN edges
+			FILTER e._from == @userKey AND e._to == @clubKey AND e.type == "coaches"
+			REMOVE e IN edges
+			RETURN OLD._key
+	`
+	bindVars := map[string]interface{}{
+		"userKey": "users/" + userKey,
+		"clubKey": "clubs/" + clubKey,
+	}
+	cursor, err := db.Database.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return t.Errorf("failed to remove coach: %w", err)
+	}
+	defer cursor.Close()
+	if !cursor.HasMore() {
+		return t.Errorf("coach not found")
+	}
+	return nil
+}
+
+// GetCoaches returns the coaches of a club with their qualifications.
+func (db *Db) GetCoaches(ctx context.Context, clubKey string) ([]Coach, error) {
+	query := `
+		FOR u, e IN 1..1 INBOUND @clubKey edges
+			FILTER e.type == "coaches"
+			SORT u.lastname, u.firstname
+			LET qualifications = (
+				FOR q, qe IN 1..1 OUTBOUND u edges
+					FILTER qe.type == "qualification"
+					SORT q.expires DESC
+					RETURN q
+			)
+			RETURN {user: u, qualifications: qualifications}
+	`
+	bindVars := map[string]interface{}{
+		"clubKey": "clubs/" + clubKey,
+	}
+
+	cursor, err := db.Database.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return nil, t.Errorf("query for coaches failed: %w", err)
+	}
+	defer cursor.Close()
+
+	result := []Coach{}
+	for {
+		var doc Coach
+		_, err := cursor.ReadDocument(ctx, &doc)
+		if shared.IsNoMoreDocuments(err) {
+			break
+		} else if err != nil {
+			return nil, t.Errorf("obtaining coach failed: %w", err)
+		}
+		result = append(result, doc)
+	}
+	return result, nil
+}
@@ -44,3 +44,60 @@ func (db *Db) GetUsers(ctx context.Context, builder QueryBuilder) ([]entities.Us
 func (db *Db) GetUsersByEmail(ctx context.Context, email string) ([]entities.User, error) {
 	return db.GetUsers(ctx, buildUsersByEmailQuery(email))
 }
+
+// buildUsersByMembershipStatusQuery returns a query and bindVars for finding users by individual membership status.
+func buildUsersByMembershipStatusQuery(status string) QueryBuilder {
+	query := "FOR user IN users FILTER user.membership.status == @status SORT user.lastname, user.firstname RETURN user"
+	bindVars := map[string]interface{}{"status": status}
+	return func() (string, map[string]interface{}) { return query, bindVars }
+}
+
+// GetUsersByMembershipStatus retrieves users by individual membership status.
+func (db *Db) GetUsersByMembershipStatus(ctx context.Context, status string) ([]entities.User, error) {
+	return db.GetUsers(ctx, buildUsersByMembershipStatusQuery(status))
+}
+
+// buildUsersByTypeQuery returns a query and bindVars for a page of the users of a profile type.
+func buildUsersByTypeQuery(profileType string, skip, limit int) QueryBuilder {
+	query := "FOR user IN users FILTER user.type == @type SORT user.lastname, user.firstname LIMIT @skip, @limit RETURN user"
+	bindVars := map[string]interface{}{"type": profileType, "skip": skip, "limit": limit}
+	return func() (string, map[string]interface{}) { return query, bindVars }
+}
+
+// GetUsersByType retrieves a page of the users of a profile type and the total number of such users.
+func (db *Db) GetUsersByType(ctx context.Context, profileType string, skip, limit int) ([]entities.User, int, error) {
+	query, bindVars := buildUsersByTypeQuery(profileType, skip, limit)()
+	cursor, err := db.Database.Query(ctx, query, &arangodb.QueryOptions{
+		BindVars: bindVars,
+		Options:  arangodb.QuerySubOptions{FullCount: true},
+	})
+	if err != nil {
+		return nil, 0, t.Errorf("query string invalid: %w", err)
+	}
+	defer cursor.Close()
+
+	var result []entities.User
+	for {
+		var doc entities.User
+		_, err := cursor.ReadDocument(ctx, &doc)
+		if shared.IsNoMoreDocuments(err) {
+			break
+		} else if err != nil {
+			return nil, 0, t.Errorf("obtaining documents failed: %w", err)
+		}
+		result = append(result, doc)
+	}
+	return result, int(cursor.Statistics().FullCountInt), nil
+}
+
+// UpdateUserProfile sets the profile type of a user and replaces all type-specific attributes,
+// so attributes of a previous type do not survive a type change.
+func (db *Db) UpdateUserProfile(ctx context.Context, key, profileType string, profile entities.Profile) error {
+	query := "UPDATE @key WITH {type: @type, profile: @profile} IN users OPTIONS {mergeObjects: false}"
+	bindVars := map[string]interface{}{"key": key, "type": profileType, "profile": profile}
+	cursor, err := db.Database.Query(ctx, query, &arangodb.QueryOptions{BindVars: bindVars})
+	if err != nil {
+		return t.Errorf("failed to update user profile: %w", err)
+	}
+	return cursor.Close()
+}
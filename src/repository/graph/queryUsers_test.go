@@ -3,6 +3,7 @@ package graph
 import (
 	"context"
 	"dpv/dpv/src/domain/entities"
+	"strings"
 	"testing"
 )
 
@@ -31,3 +32,13 @@ func TestGetUsersByEmail(t *testing.T) {
 		t.Errorf("Unexpected result: %+v", result)
 	}
 }
+
+func TestBuildUsersByTypeQuery(t *testing.T) {
+	query, bindVars := buildUsersByTypeQuery("coach", 50, 25)()
+	if !strings.Contains(query, "FILTER user.type == @type") || !strings.Contains(query, "LIMIT @skip, @limit") {
+		t.Errorf("query should filter by type and page: %s", query)
+	}
+	if bindVars["type"] != "coach" || bindVars["skip"] != 50 || bindVars["limit"] != 25 {
+		t.Errorf("unexpected bind vars: %v", bindVars)
+	}
+}
@@ -0,0 +1,64 @@
+package graph
+
+import (
+	"context"
+	"dpv/dpv/src/repository/t"
+
+	"github.com/arangodb/go-driver/v2/arangodb"
+)
+
+// WithTransaction runs fn against a copy of the database bound to a stream transaction that may
+// write the given collections. The transaction is committed if fn returns nil and aborted otherwise.
+func (db *Db) WithTransaction(ctx context.Context, write []string, fn func(tx *Db) error) error {
+	return db.Database.WithTransaction(ctx, arangodb.TransactionCollections{Write: write}, nil, nil, nil,
+		func(ctx context.Context, tx arangodb.Transaction) error {
+			txDb, err := db.bind(ctx, tx)
+			if err != nil {
+				return err
+			}
+			return fn(txDb)
+		})
+}
+
+// bind returns a copy of the database whose queries and collections run inside the transaction.
+func (db *Db) bind(ctx context.Context, tx arangodb.Transaction) (*Db, error) {
+	database, ok := tx.(arangodb.Database)
+	if !ok {
+		return nil, t.Errorf("transaction does not provide a database")
+	}
+	edges, err := tx.GetCollection(ctx, db.Edges.Name(), nil)
+	if err != nil {
+		return nil, t.Errorf("could not bind %s collection to transaction: %w", db.Edges.Name(), err)
+	}
+	txDb := &Db{Database: database, Edges: edges}
+	if txDb.Users, err = bindManager(ctx, tx, db.Users); err != nil {
+		return nil, err
+	}
+	if txDb.Clubs, err = bindManager(ctx, tx, db.Clubs); err != nil {
+		return nil, err
+	}
+	if txDb.Censuses, err = bindManager(ctx, tx, db.Censuses); err != nil {
+		return nil, err
+	}
+	if txDb.Documents, err = bindManager(ctx, tx, db.Documents); err != nil {
+		return nil, err
+	}
+	if txDb.Invitations, err = bindManager(ctx, tx, db.Invitations); err != nil {
+		return nil, err
+	}
+	if txDb.Deadlines, err = bindManager(ctx, tx, db.Deadlines); err != nil {
+		return nil, err
+	}
+	if txDb.Qualifications, err = bindManager(ctx, tx, db.Qualifications); err != nil {
+		return nil, err
+	}
+	return txDb, nil
+}
+
+func bindManager[T Entity](ctx context.Context, tx arangodb.Transaction, em EntityManager[T]) (EntityManager[T], error) {
+	collection, err := tx.GetCollection(ctx, em.Collection.Name(), nil)
+	if err != nil {
+		return em, t.Errorf("could not bind %s collection to transaction: %w", em.Collection.Name(), err)
+	}
+	return EntityManager[T]{collection, em.Constructor}, nil
+}
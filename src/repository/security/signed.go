@@ -0,0 +1,35 @@
+package security
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+)
+
+// SignToken joins the fields with "." and appends an HMAC signature over the command and fields.
+// Unlike validation tokens, signed tokens carry their payload and can be checked without a lookup.
+// Fields must not contain ".".
+func SignToken(command, secret string, fields ...string) string {
+	payload := strings.Join(fields, ".")
+	return payload + "." + signature(command, payload, secret)
+}
+
+// VerifySignedToken checks the signature of a token created by SignToken and returns its fields.
+func VerifySignedToken(command, secret, token string) ([]string, bool) {
+	i := strings.LastIndex(token, ".")
+	if i <= 0 {
+		return nil, false
+	}
+	payload, sig := token[:i], token[i+1:]
+	if !hmac.Equal([]byte(sig), []byte(signature(command, payload, secret))) {
+		return nil, false
+	}
+	return strings.Split(payload, "."), true
+}
+
+func signature(command, payload, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(command + "\x01" + payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,31 @@
+package security
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSignedToken(t *testing.T) {
+	token := SignToken("membership-card", "secret", "club", "123", "1767225599")
+
+	fields, ok := VerifySignedToken("membership-card", "secret", token)
+	if !ok || !slices.Equal(fields, []string{"club", "123", "1767225599"}) {
+		t.Fatalf("valid token rejected: %v %v", fields, ok)
+	}
+
+	if _, ok := VerifySignedToken("other-command", "secret", token); ok {
+		t.Error("token accepted for another command")
+	}
+	if _, ok := VerifySignedToken("membership-card", "other-secret", token); ok {
+		t.Error("token accepted with another secret")
+	}
+	tampered := "user" + token[len("club"):]
+	if _, ok := VerifySignedToken("membership-card", "secret", tampered); ok {
+		t.Error("tampered token accepted")
+	}
+	for _, bad := range []string{"", ".", "abc", ".sig"} {
+		if _, ok := VerifySignedToken("membership-card", "secret", bad); ok {
+			t.Errorf("malformed token %q accepted", bad)
+		}
+	}
+}
@@ -139,3 +139,28 @@ func (s *Storage) GetDocumentPath(entityType, entityKey, filename string) (strin
 
 	return path, nil
 }
+
+// DeleteDocuments removes all documents stored for an entity.
+func (s *Storage) DeleteDocuments(entityType, entityKey string) error {
+	if entityKey == "" || entityKey == "." || entityKey == ".." || entityKey != filepath.Base(entityKey) {
+		return fmt.Errorf("invalid entity key")
+	}
+	if err := os.RemoveAll(filepath.Join(s.Root, entityType, entityKey)); err != nil {
+		return fmt.Errorf("could not delete documents: %w", err)
+	}
+	return nil
+}
+
+// DeleteDocument removes a single document of an entity. A document that no longer exists is not an error.
+func (s *Storage) DeleteDocument(entityType, entityKey, filename string) error {
+	path, err := s.GetDocumentPath(entityType, entityKey, filename)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("invalid document: %w", err)
+	}
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("could not delete document: %w", err)
+	}
+	return nil
+}
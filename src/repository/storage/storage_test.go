@@ -89,3 +89,66 @@ func TestSaveDocument_MkdirError(t *testing.T) {
 		t.Error("Expected error when MkdirAll fails, but got nil")
 	}
 }
+
+func TestDeleteDocuments(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "storage-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	s := NewStorage(tempDir)
+	if _, err := s.SaveDocument("clubs", "club1", "a.txt", strings.NewReader("a")); err != nil {
+		t.Fatalf("SaveDocument failed: %v", err)
+	}
+	if _, err := s.SaveDocument("clubs", "club2", "b.txt", strings.NewReader("b")); err != nil {
+		t.Fatalf("SaveDocument failed: %v", err)
+	}
+
+	if err := s.DeleteDocuments("clubs", "club1"); err != nil {
+		t.Fatalf("DeleteDocuments failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, "clubs", "club1")); !os.IsNotExist(err) {
+		t.Error("Expected documents of club1 to be deleted")
+	}
+	if docs, _ := s.ListDocuments("clubs", "club2"); len(docs) != 1 {
+		t.Errorf("Expected documents of club2 to be kept, got %d", len(docs))
+	}
+
+	for _, key := range []string{"", ".", "..", "../clubs", "club2/.."} {
+		if err := s.DeleteDocuments("clubs", key); err == nil {
+			t.Errorf("Expected error for entity key %q", key)
+		}
+	}
+	if docs, _ := s.ListDocuments("clubs", "club2"); len(docs) != 1 {
+		t.Error("Invalid keys must not delete anything")
+	}
+}
+
+func TestDeleteDocument(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "storage-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	s := NewStorage(tempDir)
+	old, err := s.SaveDocument("qualifications", "q1", "old.pdf", strings.NewReader("old"))
+	if err != nil {
+		t.Fatalf("SaveDocument failed: %v", err)
+	}
+	if _, err := s.SaveDocument("qualifications", "q1", "new.pdf", strings.NewReader("new")); err != nil {
+		t.Fatalf("SaveDocument failed: %v", err)
+	}
+
+	if err := s.DeleteDocument("qualifications", "q1", old); err != nil {
+		t.Fatalf("DeleteDocument failed: %v", err)
+	}
+	docs, _ := s.ListDocuments("qualifications", "q1")
+	if len(docs) != 1 || docs[0].Name == old {
+		t.Errorf("Expected only the new document to be kept, got %v", docs)
+	}
+	if err := s.DeleteDocument("qualifications", "q1", old); err != nil {
+		t.Errorf("Deleting a missing document should succeed, got %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package t
 
 import (
 	"dpv/dpv/src/repository/dpv"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -9,8 +10,11 @@ import (
 
 // TranslatableError captures the intent to translate.
 type TranslatableError struct {
-	Key  string
-	Args []any
+	Key       string
+	Args      []any
+	notFound  bool
+	forbidden bool
+	conflict  bool
 }
 
 // Error implements the standard error interface with a fallback (e.g. English).
@@ -37,6 +41,63 @@ func Errorf(key string, args ...any) error {
 	}
 }
 
+// NotFound marks a translatable error as reporting something that does not exist, see IsNotFound.
+func NotFound(err error) error {
+	if tErr, ok := err.(*TranslatableError); ok {
+		tErr.notFound = true
+	}
+	return err
+}
+
+// IsNotFound reports whether err or any error it wraps was marked with NotFound.
+func IsNotFound(err error) bool {
+	for err != nil {
+		if tErr, ok := err.(*TranslatableError); ok && tErr.notFound {
+			return true
+		}
+		err = errors.Unwrap(err)
+	}
+	return false
+}
+
+// Forbidden marks a translatable error as reporting a missing permission, see IsForbidden.
+func Forbidden(err error) error {
+	if tErr, ok := err.(*TranslatableError); ok {
+		tErr.forbidden = true
+	}
+	return err
+}
+
+// IsForbidden reports whether err or any error it wraps was marked with Forbidden.
+func IsForbidden(err error) bool {
+	for err != nil {
+		if tErr, ok := err.(*TranslatableError); ok && tErr.forbidden {
+			return true
+		}
+		err = errors.Unwrap(err)
+	}
+	return false
+}
+
+// Conflict marks a translatable error as reporting a change that does not fit the current state, see IsConflict.
+func Conflict(err error) error {
+	if tErr, ok := err.(*TranslatableError); ok {
+		tErr.conflict = true
+	}
+	return err
+}
+
+// IsConflict reports whether err or any error it wraps was marked with Conflict.
+func IsConflict(err error) bool {
+	for err != nil {
+		if tErr, ok := err.(*TranslatableError); ok && tErr.conflict {
+			return true
+		}
+		err = errors.Unwrap(err)
+	}
+	return false
+}
+
 // Translate recursively translates a TranslatableError and its nested errors.
 func Translate(err error, langMap map[string]string) string {
 	if err == nil {
@@ -89,6 +150,18 @@ func T(err error, lang string) string {
 	return Translate(err, GetMapFor(lang))
 }
 
+// Translations returns the key followed by its translations in all loaded languages,
+// e.g. to recognise localized input such as column headers.
+func Translations(key string) []string {
+	result := []string{key}
+	for _, m := range languages {
+		if v, ok := m[key]; ok {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
 func LoadLanguages(config *dpv.Config) error {
 	if config == nil {
 		return fmt.Errorf("config is not initialized")
@@ -88,3 +88,57 @@ func TestGetMapFor(t *testing.T) {
 		}
 	}
 }
+
+func TestTranslations(t *testing.T) {
+	languages["de"] = map[string]string{"Gender": "Geschlecht"}
+	languages["fr"] = map[string]string{"Gender": "Sexe"}
+	languages["pl"] = map[string]string{}
+
+	got := Translations("Gender")
+	if len(got) != 3 || got[0] != "Gender" {
+		t.Fatalf("Translations() = %v", got)
+	}
+	found := map[string]bool{}
+	for _, v := range got {
+		found[v] = true
+	}
+	if !found["Geschlecht"] || !found["Sexe"] {
+		t.Errorf("Translations() = %v, want German and French translations", got)
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	missing := NotFound(Errorf("club not found"))
+	if !IsNotFound(missing) {
+		t.Error("IsNotFound() = false for a NotFound error")
+	}
+	if !IsNotFound(Errorf("failed to load club: %w", missing)) {
+		t.Error("IsNotFound() = false for a wrapped NotFound error")
+	}
+	if IsNotFound(Errorf("query failed: %w", errors.New("timeout"))) || IsNotFound(nil) {
+		t.Error("IsNotFound() = true for other errors")
+	}
+	if T(missing, "en") != "club not found" {
+		t.Errorf("T() = %q", T(missing, "en"))
+	}
+}
+
+func TestIsForbidden(t *testing.T) {
+	denied := Forbidden(Errorf("unauthorized: you are not a board member or admin"))
+	if !IsForbidden(denied) || !IsForbidden(Errorf("failed to get census: %w", denied)) {
+		t.Error("IsForbidden() = false for a Forbidden error")
+	}
+	if IsForbidden(NotFound(Errorf("club not found"))) || IsForbidden(nil) {
+		t.Error("IsForbidden() = true for other errors")
+	}
+}
+
+func TestIsConflict(t *testing.T) {
+	changed := Conflict(Errorf("the census has been changed meanwhile, please reload it"))
+	if !IsConflict(changed) || !IsConflict(Errorf("failed to submit census: %w", changed)) {
+		t.Error("IsConflict() = false for a Conflict error")
+	}
+	if IsConflict(Forbidden(Errorf("unauthorized"))) || IsConflict(nil) {
+		t.Error("IsConflict() = true for other errors")
+	}
+}
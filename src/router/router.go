@@ -1,9 +1,12 @@
 package router
 
 import (
+	"context"
 	"dpv/dpv/src/api"
 	censusEndpoints "dpv/dpv/src/endpoints/census"
 	"dpv/dpv/src/endpoints/clubs"
+	membershipEndpoints "dpv/dpv/src/endpoints/membership"
+	"dpv/dpv/src/endpoints/qualifications"
 	"dpv/dpv/src/endpoints/users"
 	"dpv/dpv/src/middleware"
 	"dpv/dpv/src/repository/dpv"
@@ -12,6 +15,8 @@ import (
 	"dpv/dpv/src/repository/t"
 	"dpv/dpv/src/service/census"
 	"dpv/dpv/src/service/club"
+	"dpv/dpv/src/service/membership"
+	"dpv/dpv/src/service/qualification"
 	"dpv/dpv/src/service/user"
 	"log"
 	"net/http"
@@ -50,10 +55,22 @@ func NewServer(configPath string, test bool) *http.Server {
 	st := storage.NewStorage(dpv.ConfigInstance.Storage.DocumentPath)
 	clubService := club.NewService(db, st)
 	clubHandler := clubs.NewHandler(clubService)
+	if !test && config.Settings.WebsiteCheckHours > 0 {
+		clubService.StartWebsiteChecks(context.Background(), time.Duration(config.Settings.WebsiteCheckHours)*time.Hour)
+	}
 
 	censusService := census.NewService(db)
 	censusHandler := censusEndpoints.NewHandler(censusService)
 
+	membershipService := membership.NewService(db)
+	membershipHandler := membershipEndpoints.NewHandler(membershipService)
+
+	qualificationService := qualification.NewService(db, st)
+	qualificationHandler := qualifications.NewHandler(qualificationService)
+	if !test && config.Settings.ReminderCheckHours > 0 {
+		qualificationService.StartExpiryReminders(context.Background(), time.Duration(config.Settings.ReminderCheckHours)*time.Hour)
+	}
+
 	r.GlobalOPTIONS = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Header.Get("Access-Control-Request-Method") != "" {
 			header := w.Header()
@@ -66,6 +83,7 @@ func NewServer(configPath string, test bool) *http.Server {
 	})
 
 	r.GET("/dpv/version", middleware.CORSMiddleware(Version))
+	r.GET("/dpv/verify/:token", middleware.CORSMiddleware(membershipHandler.Verify))
 	r.POST("/dpv/users", middleware.CORSMiddleware(userHandler.Register))
 	r.GET("/dpv/users/me", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(userHandler.Me, db)))
 	r.PATCH("/dpv/users/me", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(userHandler.UpdateMe, db)))
@@ -78,27 +96,91 @@ func NewServer(configPath string, test bool) *http.Server {
 	r.POST("/dpv/users/reset-password", middleware.CORSMiddleware(userHandler.HandleResetPassword))
 	r.PATCH("/dpv/admin/users/:key/roles", middleware.CORSMiddleware(userHandler.UpdateRoles))
 
+	r.GET("/dpv/users/types", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(userHandler.ProfileTypes, db)))
+	r.PUT("/dpv/users/me/profile", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(userHandler.UpdateProfile, db)))
+	r.GET("/dpv/admin/users", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(userHandler.ListUsers, db)))
+
+	r.GET("/dpv/users/me/qualifications", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(qualificationHandler.List, db)))
+	r.POST("/dpv/users/me/qualifications", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(qualificationHandler.Create, db)))
+	r.PUT("/dpv/users/me/qualifications/:key", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(qualificationHandler.Update, db)))
+	r.DELETE("/dpv/users/me/qualifications/:key", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(qualificationHandler.Delete, db)))
+	r.POST("/dpv/users/me/qualifications/:key/certificate", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(qualificationHandler.UploadCertificate, db)))
+	r.GET("/dpv/qualifications/:key/certificate", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(qualificationHandler.GetCertificate, db)))
+	r.GET("/dpv/admin/qualifications", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(qualificationHandler.ListByStatus, db)))
+	r.POST("/dpv/admin/qualifications/:key/verify", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(qualificationHandler.Verify, db)))
+	r.POST("/dpv/admin/qualifications/:key/reject", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(qualificationHandler.Reject, db)))
+
+	r.POST("/dpv/users/me/membership/apply", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(userHandler.ApplyMembership, db)))
+	r.POST("/dpv/users/me/membership/cancel", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(userHandler.CancelMembership, db)))
+	r.GET("/dpv/users/me/membership/card", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(userHandler.MembershipCard, db)))
+	r.PUT("/dpv/users/me/membership/payment", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(userHandler.UpdatePaymentDetails, db)))
+	r.GET("/dpv/admin/members", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(userHandler.ListMembers, db)))
+	r.POST("/dpv/admin/users/:key/membership/approve", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(userHandler.ApproveMembership, db)))
+	r.POST("/dpv/admin/users/:key/membership/deny", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(userHandler.DenyMembership, db)))
+
+	r.GET("/dpv/directory/clubs", middleware.CORSMiddleware(clubHandler.Directory))
+
 	r.POST("/dpv/clubs", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(clubHandler.Create, db)))
 	r.GET("/dpv/clubs", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(clubHandler.List, db)))
-	r.GET("/dpv/clubs/:key", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(clubHandler.Get, db)))
+	r.POST("/dpv/admin/clubs/bulk", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(clubHandler.Bulk, db)))
+	// httprouter does not allow a static segment next to :key, so the public proximity search is dispatched here
+	getClub := middleware.BasicAuthMiddleware(clubHandler.Get, db)
+	r.GET("/dpv/clubs/:key", middleware.CORSMiddleware(func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		if ps.ByName("key") == "near" {
+			clubHandler.Nearby(w, r, ps)
+			return
+		}
+		getClub(w, r, ps)
+	}))
 	r.PATCH("/dpv/clubs/:key", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(clubHandler.Update, db)))
 	r.DELETE("/dpv/clubs/:key", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(clubHandler.Delete, db)))
 
 	r.POST("/dpv/clubs/:key/apply", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(clubHandler.Apply, db)))
 	r.POST("/dpv/clubs/:key/approve", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(clubHandler.Approve, db)))
 	r.POST("/dpv/clubs/:key/deny", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(clubHandler.Deny, db)))
+	r.POST("/dpv/clubs/:key/restore", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(clubHandler.Restore, db)))
+	r.POST("/dpv/clubs/:key/purge", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(clubHandler.Purge, db)))
 	r.POST("/dpv/clubs/:key/cancel", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(clubHandler.Cancel, db)))
 	r.POST("/dpv/clubs/:key/documents", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(clubHandler.UploadDocument, db)))
 	r.GET("/dpv/clubs/:key/documents", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(clubHandler.ListDocuments, db)))
 	r.GET("/dpv/clubs/:key/documents/:filename", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(clubHandler.GetDocument, db)))
+	r.POST("/dpv/clubs/:key/documents/:filename/accept", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(clubHandler.AcceptDocument, db)))
+	r.POST("/dpv/clubs/:key/documents/:filename/reject", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(clubHandler.RejectDocument, db)))
 	r.GET("/dpv/clubs/:key/download-documents", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(clubHandler.DownloadAllDocuments, db)))
 	r.GET("/dpv/clubs/:key/payment-details", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(clubHandler.GetPaymentDetails, db)))
+	r.GET("/dpv/clubs/:key/website-verification", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(clubHandler.GetWebsiteVerification, db)))
+	r.POST("/dpv/clubs/:key/website-verification", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(clubHandler.VerifyWebsite, db)))
 
 	r.POST("/dpv/clubs/:key/owners", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(clubHandler.AddOwner, db)))
+	r.PATCH("/dpv/clubs/:key/owners/:userKey", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(clubHandler.UpdateOwner, db)))
 	r.DELETE("/dpv/clubs/:key/owners/:userKey", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(clubHandler.RemoveOwner, db)))
+	r.GET("/dpv/clubs/:key/card", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(clubHandler.Card, db)))
+	r.GET("/dpv/clubs/:key/coaches", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(clubHandler.ListCoaches, db)))
+	r.POST("/dpv/clubs/:key/coaches", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(clubHandler.AddCoach, db)))
+	r.DELETE("/dpv/clubs/:key/coaches/:userKey", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(clubHandler.RemoveCoach, db)))
+	r.GET("/dpv/clubs/:key/invitations", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(clubHandler.ListInvitations, db)))
+	r.DELETE("/dpv/clubs/:key/invitations/:invitationKey", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(clubHandler.RevokeInvitation, db)))
+	r.GET("/dpv/invitations/:key", middleware.CORSMiddleware(clubHandler.ShowInvitation))
+	r.POST("/dpv/invitations/:key/accept", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(clubHandler.AcceptInvitation, db)))
 
 	r.GET("/dpv/clubs/:key/census/:year", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(censusHandler.Get, db)))
 	r.PUT("/dpv/clubs/:key/census/:year", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(censusHandler.Upsert, db)))
+	r.GET("/dpv/clubs/:key/census/:year/status", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(censusHandler.Status, db)))
+	r.GET("/dpv/clubs/:key/census/:year/statistics", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(censusHandler.ClubStatistics, db)))
+	r.GET("/dpv/clubs/:key/census/:year/diff", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(censusHandler.Diff, db)))
+	r.POST("/dpv/clubs/:key/census/:year/submit", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(censusHandler.Submit, db)))
+	r.POST("/dpv/clubs/:key/census/:year/accept", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(censusHandler.Accept, db)))
+	r.POST("/dpv/clubs/:key/census/:year/return", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(censusHandler.Return, db)))
+	r.GET("/dpv/census/deadlines", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(censusHandler.Deadlines, db)))
+	r.PUT("/dpv/admin/census/deadlines/:year", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(censusHandler.SetDeadline, db)))
+	r.PUT("/dpv/admin/census/deadlines/:year/extensions/:clubKey", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(censusHandler.GrantExtension, db)))
+	r.GET("/dpv/admin/census/deadlines/:year/overdue", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(censusHandler.Overdue, db)))
+	r.POST("/dpv/admin/census/genders/normalize", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(censusHandler.NormalizeGenders, db)))
+	r.GET("/dpv/admin/census/statistics/:year", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(censusHandler.Statistics, db)))
+	r.GET("/dpv/admin/census/bestandserhebung/:year", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(censusHandler.Bestandserhebung, db)))
+	r.GET("/dpv/admin/census/swings/:year", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(censusHandler.Swings, db)))
+	r.GET("/dpv/admin/census/duplicates/:year", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(censusHandler.Duplicates, db)))
+	r.GET("/dpv/admin/census/review/:year", middleware.CORSMiddleware(middleware.BasicAuthMiddleware(censusHandler.ListByStatus, db)))
 	r.GET("/dpv/census/sample", middleware.CORSMiddleware(func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 		censusHandler.DownloadSample(w, r)
 	}))
@@ -204,3 +204,28 @@ func TestDocumentUpload(t *testing.T) {
 		t.Errorf("unexpected response: %s", string(resBody))
 	}
 }
+
+func TestPublicDirectory(t *testing.T) {
+	server := setupServer(t, "8085")
+	defer server.Close()
+
+	resp, err := http.Get("http://localhost:8085/dpv/directory/clubs?format=csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/csv") {
+		t.Errorf("unexpected content-type: %s", resp.Header.Get("Content-Type"))
+	}
+	if resp.Header.Get("ETag") == "" || resp.Header.Get("Cache-Control") == "" {
+		t.Error("directory response should be cacheable")
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if !strings.HasPrefix(string(body), "name,city,website,email,parent") {
+		t.Errorf("unexpected CSV header: %s", string(body))
+	}
+}
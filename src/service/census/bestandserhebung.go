@@ -0,0 +1,140 @@
+package census
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"dpv/dpv/src/domain/dtos"
+	"dpv/dpv/src/domain/entities"
+	"dpv/dpv/src/repository/dpv"
+	"dpv/dpv/src/repository/geo"
+	"dpv/dpv/src/repository/graph"
+	"dpv/dpv/src/repository/t"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// bestandserhebungGenders are the gender columns of the Bestandserhebung with their abbreviation.
+var bestandserhebungGenders = []struct {
+	gender string
+	column string
+}{
+	{entities.GenderMale, "m"},
+	{entities.GenderFemale, "w"},
+	{entities.GenderDiverse, "d"},
+	{entities.GenderUnspecified, "o"}, // ohne Angabe
+}
+
+// SportCode returns the configured sport code of the federation for the Bestandserhebung.
+func SportCode() string {
+	if dpv.ConfigInstance != nil {
+		return dpv.ConfigInstance.Settings.CensusSportCode
+	}
+	return ""
+}
+
+// Bestandserhebung counts the accepted censuses of a year per federal state with the configured age brackets
+// (Admin only). The state is the one set on the club or derived from its postcode, clubs without either are
+// counted under "".
+func (s *Service) Bestandserhebung(ctx context.Context, year int) (map[string]*dtos.CensusStatistics, error) {
+	bounds := AgeBrackets()
+	clubs, err := s.Db.CountCensusMembersByClub(ctx, year, bounds)
+	if err != nil {
+		return nil, err
+	}
+	byState := make(map[string]*dtos.CensusStatistics)
+	for _, club := range clubs {
+		state := clubState(club)
+		stats, ok := byState[state]
+		if !ok {
+			stats = newStatistics(year, bounds)
+			byState[state] = stats
+		}
+		stats.Clubs++
+		for _, c := range club.Counts {
+			if c.Bracket >= 0 && c.Bracket < len(bounds) {
+				addCount(stats, c.Bracket, c.Gender, c.Count)
+			}
+		}
+	}
+	return byState, nil
+}
+
+// clubState returns the federal state set on a club, or the one of the postcode in its address.
+func clubState(club graph.ClubCensusCounts) string {
+	if _, ok := geo.FederalStates[club.FederalState]; ok {
+		return club.FederalState
+	}
+	return geo.FederalState(geo.ExtractPostcode(club.Address))
+}
+
+// BestandserhebungCSV returns the Bestandserhebung of a federal state as semicolon separated CSV with a
+// UTF-8 BOM, as expected by spreadsheet applications. Like the Landessportbund survey it aggregates the
+// members of all clubs per sport and age bracket, with a column per gender (m, w, d, o for ohne Angabe),
+// followed by a total row with the number of clubs. Without statistics the configured brackets are empty.
+func BestandserhebungCSV(year int, state, sportCode string, stats *dtos.CensusStatistics) []byte {
+	if stats == nil {
+		stats = newStatistics(year, AgeBrackets())
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteString("\ufeff")
+	writer := csv.NewWriter(&buffer)
+	writer.Comma = ';'
+
+	header := []string{"Jahr", "Bundesland", "Sportart", "Altersgruppe"}
+	for _, g := range bestandserhebungGenders {
+		header = append(header, g.column)
+	}
+	writer.Write(append(header, "Gesamt", "Vereine"))
+
+	row := func(label string, genders map[string]int, total int, clubs string) []string {
+		r := []string{strconv.Itoa(year), state, sportCode, label}
+		for _, g := range bestandserhebungGenders {
+			r = append(r, strconv.Itoa(genders[g.gender]))
+		}
+		return append(r, strconv.Itoa(total), clubs)
+	}
+	for _, b := range stats.Brackets {
+		writer.Write(row(b.Label, b.Genders, b.Total, ""))
+	}
+	writer.Write(row("Summe", stats.Genders, stats.Total, strconv.Itoa(stats.Clubs)))
+
+	writer.Flush()
+	return buffer.Bytes()
+}
+
+// BestandserhebungFilename returns the file name of a federal state's Bestandserhebung.
+func BestandserhebungFilename(year int, state string) string {
+	if state == "" {
+		state = "unknown"
+	}
+	return fmt.Sprintf("bestandserhebung_%d_%s.csv", year, state)
+}
+
+// BestandserhebungZip returns a zip archive with the Bestandserhebung of every federal state with censuses.
+func BestandserhebungZip(year int, sportCode string, byState map[string]*dtos.CensusStatistics) ([]byte, error) {
+	states := make([]string, 0, len(byState))
+	for state := range byState {
+		states = append(states, state)
+	}
+	sort.Strings(states)
+
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+	for _, state := range states {
+		f, err := archive.Create(BestandserhebungFilename(year, state))
+		if err != nil {
+			return nil, t.Errorf("failed to create export archive: %w", err)
+		}
+		if _, err := f.Write(BestandserhebungCSV(year, state, sportCode, byState[state])); err != nil {
+			return nil, t.Errorf("failed to create export archive: %w", err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, t.Errorf("failed to create export archive: %w", err)
+	}
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,72 @@
+package census
+
+import (
+	"archive/zip"
+	"bytes"
+	"dpv/dpv/src/domain/dtos"
+	"dpv/dpv/src/domain/entities"
+	"dpv/dpv/src/repository/graph"
+	"strings"
+	"testing"
+)
+
+func TestClubState(t *testing.T) {
+	if got := clubState(graph.ClubCensusCounts{FederalState: "HE", Address: "Marienplatz 1, 80331 München"}); got != "HE" {
+		t.Errorf("clubState() = %q, want explicit state", got)
+	}
+	if got := clubState(graph.ClubCensusCounts{Address: "Marienplatz 1, 80331 München"}); got != "BY" {
+		t.Errorf("clubState() = %q, want state of postcode", got)
+	}
+	if got := clubState(graph.ClubCensusCounts{Address: "unbekannt"}); got != "" {
+		t.Errorf("clubState() = %q, want unknown", got)
+	}
+}
+
+func TestBestandserhebungCSV(t *testing.T) {
+	stats := countMembers(&entities.Census{Year: 2024, Members: []entities.MemberRow{
+		{Gender: entities.GenderFemale, BirthYear: 2010},
+		{Gender: entities.GenderMale, BirthYear: 1990},
+		{Gender: entities.GenderDiverse, BirthYear: 2012},
+	}}, []int{0, 19})
+	stats.Clubs = 2
+
+	out := string(BestandserhebungCSV(2024, "BY", "PK", stats))
+	if !strings.HasPrefix(out, "\ufeff") {
+		t.Errorf("BestandserhebungCSV() has no BOM")
+	}
+	lines := strings.Split(strings.TrimSpace(strings.TrimPrefix(out, "\ufeff")), "\n")
+	want := []string{
+		"Jahr;Bundesland;Sportart;Altersgruppe;m;w;d;o;Gesamt;Vereine",
+		"2024;BY;PK;0-18;0;1;1;0;2;",
+		"2024;BY;PK;19+;1;0;0;0;1;",
+		"2024;BY;PK;Summe;1;1;1;0;3;2",
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("BestandserhebungCSV() = %q", out)
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("line %d = %q, want %q", i+1, lines[i], want[i])
+		}
+	}
+
+	empty := strings.Split(strings.TrimSpace(string(BestandserhebungCSV(2024, "HB", "PK", nil))), "\n")
+	if len(empty) != len(DefaultAgeBrackets)+2 || empty[len(empty)-1] != "2024;HB;PK;Summe;0;0;0;0;0;0" {
+		t.Errorf("BestandserhebungCSV(nil) = %q", empty)
+	}
+}
+
+func TestBestandserhebungZip(t *testing.T) {
+	byState := map[string]*dtos.CensusStatistics{"BY": nil, "": nil}
+	content, err := BestandserhebungZip(2024, "PK", byState)
+	if err != nil {
+		t.Fatalf("BestandserhebungZip() error = %v", err)
+	}
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(archive.File) != 2 || archive.File[0].Name != "bestandserhebung_2024_unknown.csv" || archive.File[1].Name != "bestandserhebung_2024_BY.csv" {
+		t.Errorf("BestandserhebungZip() files = %v", archive.File)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"dpv/dpv/src/api"
+	"dpv/dpv/src/domain/dtos"
 	"dpv/dpv/src/domain/entities"
 	"dpv/dpv/src/repository/graph"
 	"dpv/dpv/src/repository/t"
@@ -12,8 +13,25 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
+// suspiciousAge is the age above which a member is reported with a warning.
+const suspiciousAge = 100
+
+// birthDateLayouts are the accepted formats of the optional birth date column.
+var birthDateLayouts = []string{"2006-01-02", "2.1.2006"}
+
+// parseBirthDate parses a birth date like "2010-03-15" or "15.03.2010".
+func parseBirthDate(s string) (time.Time, bool) {
+	for _, layout := range birthDateLayouts {
+		if date, err := time.Parse(layout, s); err == nil {
+			return date, true
+		}
+	}
+	return time.Time{}, false
+}
+
 type Service struct {
 	Db *graph.Db
 }
@@ -30,20 +48,71 @@ func (s *Service) Get(ctx context.Context, clubKey string, year int, user *entit
 		return nil, t.Errorf("authorization check failed while getting census: %w", err)
 	}
 	if !authorized {
-		return nil, t.Errorf("unauthorized: you are not a board member or admin")
+		return nil, t.Forbidden(t.Errorf("unauthorized: you are not a board member or admin"))
 	}
 	return s.Db.GetCensus(ctx, clubKey, year)
 }
 
+// Upsert stores a census. New censuses are drafts, uploading a draft or returned census again keeps its
+// workflow state and review, see reuploaded.
 func (s *Service) Upsert(ctx context.Context, clubKey string, censusData *entities.Census, user *entities.User) error {
 	authorized, err := s.IsAuthorized(ctx, user, clubKey)
 	if err != nil {
 		return t.Errorf("authorization check failed while upserting census: %w", err)
 	}
 	if !authorized {
-		return t.Errorf("unauthorized: you are not a board member or admin")
+		return t.Forbidden(t.Errorf("unauthorized: you are not a board member or admin"))
+	}
+	if err := s.CheckOpen(ctx, clubKey, censusData.Year, user); err != nil {
+		return err
+	}
+	existing, err := s.Db.GetCensus(ctx, clubKey, censusData.Year)
+	if t.IsNotFound(err) {
+		censusData.Status = entities.CensusDraft
+		return s.Db.UpsertCensus(ctx, clubKey, censusData)
+	} else if err != nil {
+		return err
+	}
+	reuploaded(existing, censusData)
+	updated, err := s.Db.UpdateCensusIfStatus(ctx, censusData, []string{existing.State()})
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return errCensusChanged
+	}
+	return nil
+}
+
+// errCensusChanged reports a census whose workflow state changed while it was being updated.
+var errCensusChanged = t.Conflict(t.Errorf("the census has been changed meanwhile, please reload it"))
+
+// reuploaded carries the workflow state of an existing census over to its new upload. Drafts and returned
+// censuses keep their state and review. Submitted and accepted censuses, which only admins can change,
+// are submitted again, so the changed census is reviewed before it is counted.
+func reuploaded(existing, census *entities.Census) {
+	census.SetKey(existing.GetKey())
+	census.Status = existing.State()
+	census.Comment = existing.Comment
+	census.SubmitterKey, census.Submitted = existing.SubmitterKey, existing.Submitted
+	census.ReviewerKey, census.Reviewed = existing.ReviewerKey, existing.Reviewed
+	if existing.IsLocked() {
+		census.Status = entities.CensusSubmitted
+		census.Comment = ""
+		census.ReviewerKey, census.Reviewed = "", time.Time{}
+	}
+}
+
+// Authorize returns an error unless the user is a board member of the club or an admin.
+func (s *Service) Authorize(ctx context.Context, clubKey string, user *entities.User) error {
+	authorized, err := s.IsAuthorized(ctx, user, clubKey)
+	if err != nil {
+		return t.Errorf("authorization check failed while validating census: %w", err)
+	}
+	if !authorized {
+		return t.Forbidden(t.Errorf("unauthorized: you are not a board member or admin"))
 	}
-	return s.Db.UpsertCensus(ctx, clubKey, censusData)
+	return nil
 }
 
 // IsAuthorized checks if a user is an admin or a board member of the club.
@@ -63,84 +132,173 @@ func (s *Service) IsAuthorized(ctx context.Context, user *entities.User, clubKey
 	return false, nil
 }
 
-// ParseAndValidateCSV parses a Census CSV and validates business rules.
+// ParseOptions control how an uploaded census file is read.
+type ParseOptions struct {
+	Sheet   string            // spreadsheet sheet, the first sheet by default
+	Mapping map[string]string // explicit column mapping, see ParseMapping
+	Lang    string            // language of the problem messages
+}
+
+// ParseAndValidate parses a census file in CSV, XLSX or ODS format, detected by content,
+// and validates business rules. For spreadsheets the named sheet, or the first sheet, is read.
+// The result reports how the file was read, e.g. the detected CSV delimiter and encoding,
+// and all problems found. If the file has errors, the result without census is returned
+// together with an error; unreadable files only return an error.
+func (s *Service) ParseAndValidate(reader io.Reader, year int, opts ParseOptions) (*dtos.CensusUpload, error) {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, t.Errorf("failed to read file: %w", err)
+	}
+	format, err := detectFormat(content)
+	if err != nil {
+		return nil, err
+	}
+
+	var records [][]string
+	file := dtos.CensusFile{Format: format}
+	switch format {
+	case FormatXLSX:
+		records, file.Sheet, err = readXLSX(content, opts.Sheet)
+	case FormatODS:
+		records, file.Sheet, err = readODS(content, opts.Sheet)
+	default:
+		records, file, err = readCSV(content)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if format != FormatCSV {
+		records = normalizeSpreadsheetRows(records)
+	}
+
+	census, r := validateRecords(records, year, opts.Mapping)
+	upload := &dtos.CensusUpload{File: file, Problems: r.translate(opts.Lang)}
+	if n := r.errors(); n > 0 {
+		return upload, t.Errorf("census file has %d errors", n)
+	}
+	upload.Census = census
+	return upload, nil
+}
+
+// ParseAndValidateCSV parses a Census CSV and validates business rules, returning the first error.
+// The delimiter and encoding are detected from the content.
 func (s *Service) ParseAndValidateCSV(reader io.Reader, year int) (*entities.Census, error) {
-	csvReader := csv.NewReader(reader)
-	records, err := csvReader.ReadAll()
+	content, err := io.ReadAll(reader)
 	if err != nil {
-		// csv.ReadAll can return partial records on error
 		return nil, t.Errorf("failed to read CSV: %w", err)
 	}
+	records, _, err := readCSV(content)
+	if err != nil {
+		return nil, err
+	}
+	census, r := validateRecords(records, year, nil)
+	if err := r.firstError(); err != nil {
+		return nil, err
+	}
+	return census, nil
+}
 
+// validateRecords checks the records against the business rules and reports all problems.
+// Columns are found by header or explicit mapping, genders are normalised to their canonical
+// value and rows repeating an earlier person are reported as warnings. The census holds the valid rows.
+func validateRecords(records [][]string, year int, mapping map[string]string) (*entities.Census, report) {
+	var r report
 	if len(records) == 0 {
-		return nil, t.Errorf("CSV file is empty")
+		r.errorAt(0, 0, "", t.Errorf("CSV file is empty"))
+		return nil, r
 	}
 
-	// Heuristic: skip first line if the birth year column is not a number.
-	startIndex := 0
-	if len(records[0]) == 4 {
-		if _, err := strconv.Atoi(strings.TrimSpace(records[0][2])); err != nil {
-			startIndex = 1
-		}
-	} else {
-		return nil, t.Errorf("CSV must have exactly 4 columns: Firstname, Lastname, Birthyear, Gender")
+	columns, startIndex, ok := resolveColumns(records, mapping, &r)
+	if !ok {
+		return nil, r
 	}
+	width := len(records[0])
+	pos := func(column string) int { return columns[column] + 1 }
+	genders := genderAliases()
 
 	var members []entities.MemberRow
+	var lines []int
 	for i := startIndex; i < len(records); i++ {
 		row := records[i]
 		lineNum := i + 1
 
-		if len(row) != 4 {
-			return nil, t.Errorf("line %d: expected 4 columns, got %d", lineNum, len(row))
+		if len(row) != width {
+			r.errorAt(lineNum, 0, "", t.Errorf("line %d: expected %d columns, got %d", lineNum, width, len(row)))
+			continue
 		}
 
 		if isRowEmpty(row) {
 			// Blank rows are not allowed in between
-			return nil, t.Errorf("line %d: blank row found", lineNum)
+			r.errorAt(lineNum, 0, "", t.Errorf("line %d: blank row found", lineNum))
+			continue
 		}
 
-		firstname := strings.TrimSpace(row[0])
-		lastname := strings.TrimSpace(row[1])
-		birthYearStr := strings.TrimSpace(row[2])
-		gender := strings.TrimSpace(row[3])
+		firstname := strings.TrimSpace(row[columns[ColumnFirstname]])
+		lastname := strings.TrimSpace(row[columns[ColumnLastname]])
+		birthYearStr := strings.TrimSpace(row[columns[ColumnBirthyear]])
+		gender := strings.TrimSpace(row[columns[ColumnGender]])
+		problems := len(r)
 
 		// Names and Gender must not be purely numeric
 		if isNumeric(firstname) {
-			return nil, t.Errorf("line %d: Firstname contains only numbers", lineNum)
+			r.errorAt(lineNum, pos(ColumnFirstname), ColumnFirstname, t.Errorf("line %d: Firstname contains only numbers", lineNum))
 		}
 		if isNumeric(lastname) {
-			return nil, t.Errorf("line %d: Lastname contains only numbers", lineNum)
+			r.errorAt(lineNum, pos(ColumnLastname), ColumnLastname, t.Errorf("line %d: Lastname contains only numbers", lineNum))
 		}
 		if isNumeric(gender) {
-			return nil, t.Errorf("line %d: Gender contains only numbers", lineNum)
+			r.errorAt(lineNum, pos(ColumnGender), ColumnGender, t.Errorf("line %d: Gender contains only numbers", lineNum))
+		} else if canonical, ok := genders[normalizeGender(gender)]; ok {
+			gender = canonical
+		} else {
+			r.errorAt(lineNum, pos(ColumnGender), ColumnGender, t.Errorf("line %d: unknown gender '%s', accepted are %s", lineNum, gender, acceptedGenders))
 		}
 
 		birthYear, err := strconv.Atoi(birthYearStr)
 		if err != nil {
-			return nil, t.Errorf("line %d: invalid birth year '%s'", lineNum, birthYearStr)
+			r.errorAt(lineNum, pos(ColumnBirthyear), ColumnBirthyear, t.Errorf("line %d: invalid birth year '%s'", lineNum, birthYearStr))
+		} else {
+			// Validation: Age must be 2 to 120 relative to report year, above 100 it is likely a typo.
+			age := year - birthYear
+			if age < 2 {
+				r.errorAt(lineNum, pos(ColumnBirthyear), ColumnBirthyear, t.Errorf("line %d: age %d is too young (minimum 2 years)", lineNum, age))
+			} else if age > 120 {
+				r.errorAt(lineNum, pos(ColumnBirthyear), ColumnBirthyear, t.Errorf("line %d: age %d is too old (maximum 120 years)", lineNum, age))
+			} else if age > suspiciousAge {
+				r.warnAt(lineNum, pos(ColumnBirthyear), ColumnBirthyear, t.Errorf("line %d: age %d is unusually high, please check the birth year", lineNum, age))
+			}
 		}
-		// Validation: Age must be 2 to 120 relative to report year.
-		age := year - birthYear
-		if age < 2 {
-			return nil, t.Errorf("line %d: age %d is too young (minimum 2 years)", lineNum, age)
+		birthDate := ""
+		if _, ok := columns[ColumnBirthdate]; ok {
+			if value := strings.TrimSpace(row[columns[ColumnBirthdate]]); value != "" {
+				date, ok := parseBirthDate(value)
+				if !ok {
+					r.errorAt(lineNum, pos(ColumnBirthdate), ColumnBirthdate, t.Errorf("line %d: invalid birth date '%s'", lineNum, value))
+				} else if err == nil && date.Year() != birthYear { // an invalid birth year is reported above
+					r.errorAt(lineNum, pos(ColumnBirthdate), ColumnBirthdate, t.Errorf("line %d: birth date '%s' does not match birth year %d", lineNum, value, birthYear))
+				}
+				birthDate = date.Format("2006-01-02")
+			}
 		}
-		if age > 120 {
-			return nil, t.Errorf("line %d: age %d is too old (maximum 120 years)", lineNum, age)
+		if r[problems:].errors() > 0 {
+			continue
 		}
 		members = append(members, entities.MemberRow{
 			Firstname: firstname,
 			Lastname:  lastname,
 			BirthYear: birthYear,
+			BirthDate: birthDate,
 			Gender:    gender,
 		})
+		lines = append(lines, lineNum)
 	}
+	warnDuplicates(members, lines, &r)
 
 	return &entities.Census{
 		Year:        year,
 		MemberCount: len(members),
 		Members:     members,
-	}, nil
+	}, r
 }
 
 func isRowEmpty(row []string) bool {
@@ -0,0 +1,256 @@
+package census
+
+import (
+	"dpv/dpv/src/repository/t"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Census columns, in the order of the sample file and of files without a header
+const (
+	ColumnFirstname = "firstname"
+	ColumnLastname  = "lastname"
+	ColumnBirthyear = "birthyear"
+	ColumnGender    = "gender"
+	ColumnBirthdate = "birthdate"
+)
+
+// Columns lists the census columns in their default order.
+var Columns = []string{ColumnFirstname, ColumnLastname, ColumnBirthyear, ColumnGender}
+
+// OptionalColumns are only read if found in the header or mapped explicitly.
+var OptionalColumns = []string{ColumnBirthdate}
+
+// allColumns returns the required and the optional census columns.
+func allColumns() []string {
+	return append(slices.Clone(Columns), OptionalColumns...)
+}
+
+// columnLabels are the English header names, also used in error messages.
+var columnLabels = map[string]string{
+	ColumnFirstname: "Firstname",
+	ColumnLastname:  "Lastname",
+	ColumnBirthyear: "Birthyear",
+	ColumnGender:    "Gender",
+	ColumnBirthdate: "Birthdate",
+}
+
+// sampleHeaderKey is the translation key of the sample file header, whose translations
+// in all loaded languages are recognised as column headers.
+const sampleHeaderKey = "Firstname,Lastname,Birthyear,Gender"
+
+// columnSynonyms are common header names besides the translated sample header.
+var columnSynonyms = map[string][]string{
+	ColumnFirstname: {"first name", "forename", "given name", "vorname", "rufname"},
+	ColumnLastname:  {"last name", "surname", "family name", "nachname", "familienname", "zuname"},
+	ColumnBirthyear: {"birth year", "year of birth", "born", "geburtsjahr", "jahrgang"},
+	ColumnGender:    {"sex", "geschlecht"},
+	ColumnBirthdate: {"birth date", "date of birth", "dob", "geburtsdatum", "geboren am"},
+}
+
+// columnMap maps census columns to their zero based index in a row.
+type columnMap map[string]int
+
+// headerAliases returns the normalised header names of all columns.
+func headerAliases() map[string]string {
+	aliases := make(map[string]string)
+	for _, column := range allColumns() {
+		aliases[normalizeHeader(columnLabels[column])] = column
+		aliases[normalizeHeader(column)] = column
+		for _, synonym := range columnSynonyms[column] {
+			aliases[normalizeHeader(synonym)] = column
+		}
+	}
+	for _, header := range t.Translations(sampleHeaderKey) {
+		for i, name := range strings.Split(header, ",") {
+			if i < len(Columns) {
+				aliases[normalizeHeader(name)] = Columns[i]
+			}
+		}
+	}
+	return aliases
+}
+
+// normalizeHeader lowercases a header and drops everything but letters and digits,
+// so "Year of birth", "year_of_birth" and "YearOfBirth" are the same.
+func normalizeHeader(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// ParseMapping parses an explicit column mapping like "firstname=Rufname,birthyear=3",
+// assigning census columns to a header name or a 1-based column number.
+func ParseMapping(s string) (map[string]string, error) {
+	mapping := make(map[string]string)
+	if strings.TrimSpace(s) == "" {
+		return mapping, nil
+	}
+	for _, pair := range strings.Split(s, ",") {
+		column, source, ok := strings.Cut(pair, "=")
+		column = strings.ToLower(strings.TrimSpace(column))
+		source = strings.TrimSpace(source)
+		if !ok || source == "" {
+			return nil, t.Errorf("invalid column mapping '%s', expected %s", strings.TrimSpace(pair), "column=header") // no '=' in the translation key
+		}
+		if _, known := columnLabels[column]; !known {
+			return nil, t.Errorf("unknown column '%s' in mapping, expected one of %s", column, strings.Join(allColumns(), ", "))
+		}
+		mapping[column] = source
+	}
+	return mapping, nil
+}
+
+// resolveColumns finds the census columns in the first row and returns their positions together with
+// the number of header rows. A row is a header if it contains at least two known column names or if
+// the mapping refers to header names. Without a header the columns are expected in the default order.
+// Ignored columns are reported as warnings; it returns false if the columns cannot be resolved.
+func resolveColumns(records [][]string, mapping map[string]string, r *report) (columnMap, int, bool) {
+	first := records[0]
+	aliases := headerAliases()
+
+	detected := columnMap{}
+	for i, cell := range first {
+		if column, ok := aliases[normalizeHeader(cell)]; ok {
+			if _, seen := detected[column]; !seen {
+				detected[column] = i
+			}
+		}
+	}
+	header := len(detected) >= 2
+	for _, source := range mapping {
+		if _, err := strconv.Atoi(source); err != nil {
+			header = true
+		}
+	}
+
+	if !header && len(mapping) == 0 {
+		if len(first) != len(Columns) {
+			r.errorAt(1, 0, "", t.Errorf("CSV must have exactly 4 columns: Firstname, Lastname, Birthyear, Gender"))
+			return nil, 0, false
+		}
+		columns := columnMap{}
+		for i, column := range Columns {
+			columns[column] = i
+		}
+		return columns, headerRows(first, columns, false), true
+	}
+
+	columns := columnMap{}
+	for _, column := range Columns {
+		source, mapped := mapping[column]
+		switch {
+		case mapped:
+			idx, err := mappedIndex(first, source, header)
+			if err != nil {
+				r.errorAt(1, 0, column, err)
+				continue
+			}
+			columns[column] = idx
+		case header:
+			idx, ok := detected[column]
+			if !ok {
+				r.errorAt(1, 0, column, t.Errorf("column '%s' not found in header", columnLabels[column]))
+				continue
+			}
+			columns[column] = idx
+		default:
+			// Numbered mapping without a header, the remaining columns keep their default position
+			columns[column] = slices.Index(Columns, column)
+		}
+	}
+	for _, column := range OptionalColumns {
+		if source, mapped := mapping[column]; mapped {
+			idx, err := mappedIndex(first, source, header)
+			if err != nil {
+				r.errorAt(1, 0, column, err)
+				continue
+			}
+			columns[column] = idx
+		} else if idx, ok := detected[column]; ok && header {
+			columns[column] = idx
+		}
+	}
+
+	used := make(map[int]string)
+	valid := countPresent(columns, Columns) == len(Columns)
+	for _, column := range allColumns() {
+		idx, ok := columns[column]
+		if !ok {
+			continue
+		}
+		if idx >= len(first) {
+			r.errorAt(1, 0, column, t.Errorf("column %d for '%s' does not exist, the file has %d columns", idx+1, columnLabels[column], len(first)))
+			valid = false
+			continue
+		}
+		if other, taken := used[idx]; taken {
+			r.errorAt(1, idx+1, column, t.Errorf("column %d is assigned to both '%s' and '%s'", idx+1, columnLabels[other], columnLabels[column]))
+			valid = false
+			continue
+		}
+		used[idx] = column
+	}
+	if !valid {
+		return nil, 0, false
+	}
+
+	for i, cell := range first {
+		if _, ok := used[i]; ok {
+			continue
+		}
+		if header {
+			r.warnAt(1, i+1, "", t.Errorf("column %d '%s' is not a census column and was ignored", i+1, strings.TrimSpace(cell)))
+		} else {
+			r.warnAt(1, i+1, "", t.Errorf("column %d is not a census column and was ignored", i+1))
+		}
+	}
+	return columns, headerRows(first, columns, header), true
+}
+
+// countPresent returns how many of the columns were resolved.
+func countPresent(columns columnMap, names []string) int {
+	n := 0
+	for _, name := range names {
+		if _, ok := columns[name]; ok {
+			n++
+		}
+	}
+	return n
+}
+
+// headerRows returns 1 if the first row is a header. Unrecognised headers are
+// skipped if the birth year column is not a number.
+func headerRows(first []string, columns columnMap, header bool) int {
+	if header {
+		return 1
+	}
+	if _, err := strconv.Atoi(strings.TrimSpace(first[columns[ColumnBirthyear]])); err != nil {
+		return 1
+	}
+	return 0
+}
+
+// mappedIndex returns the position of an explicitly mapped column, given by number or header name.
+func mappedIndex(header []string, source string, hasHeader bool) (int, error) {
+	if n, err := strconv.Atoi(source); err == nil {
+		if n < 1 {
+			return 0, t.Errorf("invalid column number %d in mapping", n)
+		}
+		return n - 1, nil
+	}
+	if hasHeader {
+		for i, cell := range header {
+			if normalizeHeader(cell) == normalizeHeader(source) {
+				return i, nil
+			}
+		}
+	}
+	return 0, t.Errorf("mapped column '%s' not found in header", source)
+}
@@ -0,0 +1,99 @@
+package census
+
+import (
+	"dpv/dpv/src/domain/entities"
+	"strings"
+	"testing"
+)
+
+func TestParseMapping(t *testing.T) {
+	mapping, err := ParseMapping(" Firstname = Rufname , birthyear=3")
+	if err != nil {
+		t.Fatalf("ParseMapping() error = %v", err)
+	}
+	if mapping[ColumnFirstname] != "Rufname" || mapping[ColumnBirthyear] != "3" || len(mapping) != 2 {
+		t.Errorf("ParseMapping() = %v", mapping)
+	}
+	if mapping, err := ParseMapping(""); err != nil || len(mapping) != 0 {
+		t.Errorf("ParseMapping(\"\") = %v, %v", mapping, err)
+	}
+	for _, invalid := range []string{"firstname", "firstname=", "club=Verein"} {
+		if _, err := ParseMapping(invalid); err == nil {
+			t.Errorf("ParseMapping(%q) expected error", invalid)
+		}
+	}
+}
+
+func TestValidateRecordsColumns(t *testing.T) {
+	tests := []struct {
+		name     string
+		records  [][]string
+		mapping  map[string]string
+		errMsg   string
+		warnings int
+	}{
+		{
+			name:    "german header in another order",
+			records: [][]string{{"Geschlecht", "Geburtsjahr", "Nachname", "Vorname"}, {"w", "1990", "Mustermann", "Erika"}},
+		},
+		{
+			name:    "english synonyms",
+			records: [][]string{{"Surname", "First name", "Year of birth", "Sex"}, {"Mustermann", "Erika", "1990", "w"}},
+		},
+		{
+			name:     "extra columns are ignored",
+			records:  [][]string{{"Mitgliedsnummer", "Vorname", "Nachname", "Jahrgang", "Geschlecht", "E-Mail"}, {"17", "Erika", "Mustermann", "1990", "w", "erika@example.com"}},
+			warnings: 2,
+		},
+		{
+			name:    "missing column",
+			records: [][]string{{"Vorname", "Nachname", "Geburtsjahr", "Verein"}, {"Erika", "Mustermann", "1990", "DPV"}},
+			errMsg:  "column 'Gender' not found",
+		},
+		{
+			name:     "mapping by header name",
+			records:  [][]string{{"Rufname", "Name", "Jahrgang", "Geschlecht"}, {"Erika", "Mustermann", "1990", "w"}},
+			mapping:  map[string]string{ColumnFirstname: "rufname", ColumnLastname: "Name"},
+			warnings: 0,
+		},
+		{
+			name:     "mapping by number without header",
+			records:  [][]string{{"1", "w", "1990", "Mustermann", "Erika"}},
+			mapping:  map[string]string{ColumnFirstname: "5", ColumnLastname: "4", ColumnGender: "2"},
+			warnings: 1,
+		},
+		{
+			name:    "mapping to unknown header",
+			records: [][]string{{"Vorname", "Nachname", "Geburtsjahr", "Geschlecht"}, {"Erika", "Mustermann", "1990", "w"}},
+			mapping: map[string]string{ColumnFirstname: "Rufname"},
+			errMsg:  "mapped column 'Rufname' not found",
+		},
+		{
+			name:    "mapping column twice",
+			records: [][]string{{"Vorname", "Nachname", "Geburtsjahr", "Geschlecht"}, {"Erika", "Mustermann", "1990", "w"}},
+			mapping: map[string]string{ColumnLastname: "1"},
+			errMsg:  "assigned to both",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			census, r := validateRecords(tt.records, 2024, tt.mapping)
+			if tt.errMsg != "" {
+				if err := r.firstError(); err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Fatalf("validateRecords() error = %v, want %q", err, tt.errMsg)
+				}
+				return
+			}
+			if err := r.firstError(); err != nil {
+				t.Fatalf("validateRecords() error = %v", err)
+			}
+			if len(r) != tt.warnings {
+				t.Errorf("validateRecords() warnings = %v, want %d", r, tt.warnings)
+			}
+			m := census.Members[0]
+			if census.MemberCount != 1 || m.Firstname != "Erika" || m.Lastname != "Mustermann" || m.BirthYear != 1990 || m.Gender != entities.GenderFemale {
+				t.Errorf("validateRecords() members = %+v", census.Members)
+			}
+		})
+	}
+}
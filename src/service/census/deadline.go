@@ -0,0 +1,160 @@
+package census
+
+import (
+	"context"
+	"dpv/dpv/src/api"
+	"dpv/dpv/src/domain/dtos"
+	"dpv/dpv/src/domain/entities"
+	"dpv/dpv/src/repository/graph"
+	"dpv/dpv/src/repository/t"
+	"time"
+)
+
+// SetDeadline sets the reporting deadline of a year (Admin only).
+func (s *Service) SetDeadline(ctx context.Context, year int, due time.Time) (*entities.CensusDeadline, error) {
+	deadline, err := s.Db.GetCensusDeadline(ctx, year)
+	if t.IsNotFound(err) {
+		deadline = &entities.CensusDeadline{Year: year}
+	} else if err != nil {
+		return nil, err
+	}
+	deadline.Due = due
+	if err := s.Db.SaveCensusDeadline(ctx, deadline); err != nil {
+		return nil, t.Errorf("failed to save census deadline: %w", err)
+	}
+	return deadline, nil
+}
+
+// GrantExtension lets a club submit its census for a year until the given day (Admin only).
+func (s *Service) GrantExtension(ctx context.Context, year int, clubKey string, until time.Time) (*entities.CensusDeadline, error) {
+	deadline, err := s.Db.GetCensusDeadline(ctx, year)
+	if err != nil {
+		return nil, err
+	}
+	if !until.After(deadline.Due) {
+		return nil, t.Errorf("extension must end after the deadline")
+	}
+	if _, err := s.Db.GetClubByKey(ctx, clubKey); err != nil {
+		return nil, t.Errorf("club not found")
+	}
+	if deadline.Extensions == nil {
+		deadline.Extensions = map[string]time.Time{}
+	}
+	deadline.Extensions[clubKey] = until
+	if err := s.Db.SaveCensusDeadline(ctx, deadline); err != nil {
+		return nil, t.Errorf("failed to save census deadline: %w", err)
+	}
+	return deadline, nil
+}
+
+// GetDeadlines lists the reporting deadlines of all years.
+func (s *Service) GetDeadlines(ctx context.Context) ([]entities.CensusDeadline, error) {
+	return s.Db.GetCensusDeadlines(ctx)
+}
+
+// Status returns whether a club has submitted its census for a year, or whether it is overdue.
+func (s *Service) Status(ctx context.Context, clubKey string, year int, user *entities.User) (*dtos.CensusStatus, error) {
+	authorized, err := s.IsAuthorized(ctx, user, clubKey)
+	if err != nil {
+		return nil, t.Errorf("authorization check failed while getting census status: %w", err)
+	}
+	if !authorized {
+		return nil, t.Forbidden(t.Errorf("unauthorized: you are not a board member or admin"))
+	}
+
+	deadline, err := s.Db.GetCensusDeadline(ctx, year)
+	if err != nil && !t.IsNotFound(err) {
+		return nil, err
+	}
+	census, err := s.Db.GetCensus(ctx, clubKey, year)
+	if err != nil && !t.IsNotFound(err) {
+		return nil, err
+	}
+	return censusStatus(clubKey, year, census, deadline, time.Now()), nil
+}
+
+func censusStatus(clubKey string, year int, census *entities.Census, deadline *entities.CensusDeadline, now time.Time) *dtos.CensusStatus {
+	status := &dtos.CensusStatus{ClubKey: clubKey, Year: year, Status: entities.CensusNotStarted}
+	if deadline != nil {
+		due := deadline.DueFor(clubKey)
+		status.Due = &due
+	}
+	if census != nil {
+		status.Status = census.State()
+		status.MemberCount = census.MemberCount
+		status.Comment = census.Comment
+	}
+	// Drafts are not submitted yet, returned censuses stay open for corrections, see CheckOpen
+	if (census == nil || census.State() == entities.CensusDraft) && deadline != nil && deadline.IsClosedFor(clubKey, now) {
+		status.Status = entities.CensusOverdue
+	}
+	return status
+}
+
+// Overdue lists the active clubs that have not submitted their census for a year after the deadline (Admin only).
+// Clubs whose census was returned for correction are not overdue.
+func (s *Service) Overdue(ctx context.Context, year int) ([]dtos.CensusStatus, error) {
+	deadline, err := s.Db.GetCensusDeadline(ctx, year)
+	if err != nil {
+		return nil, err
+	}
+	clubs, _, err := s.Db.GetClubs(ctx, graph.ClubQueryOptions{Status: "active", MissingCensusYear: year})
+	if err != nil {
+		return nil, err
+	}
+	// Returned censuses stay open for corrections after the deadline
+	returned, err := s.Db.GetCensusStatuses(ctx, year, entities.CensusReturned)
+	if err != nil {
+		return nil, err
+	}
+	open := make(map[string]bool, len(returned))
+	for _, r := range returned {
+		open[r.ClubKey] = true
+	}
+
+	now := time.Now()
+	result := []dtos.CensusStatus{}
+	for _, c := range clubs {
+		if open[c.Key] {
+			continue
+		}
+		status := censusStatus(c.Key, year, nil, deadline, now)
+		if status.Status == entities.CensusOverdue {
+			status.ClubName = c.Name
+			result = append(result, *status)
+		}
+	}
+	return result, nil
+}
+
+// CheckOpen rejects census uploads for years whose deadline has passed, unless the club has an
+// extension or its census was returned for correction, and for submitted or accepted censuses.
+// Admins may always upload.
+func (s *Service) CheckOpen(ctx context.Context, clubKey string, year int, user *entities.User) error {
+	if api.IsAdmin(*user) {
+		return nil
+	}
+	census, err := s.Db.GetCensus(ctx, clubKey, year)
+	if err == nil {
+		switch census.State() {
+		case entities.CensusSubmitted:
+			return t.Forbidden(t.Errorf("census %d has been submitted and is locked until it is reviewed", year))
+		case entities.CensusAccepted:
+			return t.Forbidden(t.Errorf("census %d has been accepted and can no longer be changed", year))
+		case entities.CensusReturned:
+			return nil // An admin asked for a correction, regardless of the deadline
+		}
+	} else if !t.IsNotFound(err) {
+		return err
+	}
+	deadline, err := s.Db.GetCensusDeadline(ctx, year)
+	if t.IsNotFound(err) {
+		return nil // No deadline configured
+	} else if err != nil {
+		return err
+	}
+	if deadline.IsClosedFor(clubKey, time.Now()) {
+		return t.Forbidden(t.Errorf("census year %d is closed since %s", year, deadline.DueFor(clubKey).Format("02.01.2006")))
+	}
+	return nil
+}
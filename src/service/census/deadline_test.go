@@ -0,0 +1,50 @@
+package census
+
+import (
+	"dpv/dpv/src/domain/entities"
+	"testing"
+	"time"
+)
+
+func TestCensusStatus(t *testing.T) {
+	due := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+	deadline := &entities.CensusDeadline{
+		Year:       2025,
+		Due:        due,
+		Extensions: map[string]time.Time{"late": due.AddDate(0, 1, 0)},
+	}
+	onDueDay := due.Add(20 * time.Hour)
+	afterDue := due.AddDate(0, 0, 2)
+
+	tests := []struct {
+		name     string
+		clubKey  string
+		census   *entities.Census
+		deadline *entities.CensusDeadline
+		now      time.Time
+		want     string
+	}{
+		{"no deadline", "club", nil, nil, afterDue, entities.CensusNotStarted},
+		{"due day is still open", "club", nil, deadline, onDueDay, entities.CensusNotStarted},
+		{"after the deadline", "club", nil, deadline, afterDue, entities.CensusOverdue},
+		{"extension granted", "late", nil, deadline, afterDue, entities.CensusNotStarted},
+		{"submitted late", "club", &entities.Census{Year: 2025, MemberCount: 12, Status: entities.CensusSubmitted}, deadline, afterDue, entities.CensusSubmitted},
+		{"stored before the workflow", "club", &entities.Census{Year: 2025, MemberCount: 12}, deadline, afterDue, entities.CensusAccepted},
+		{"draft before the deadline", "club", &entities.Census{Year: 2025, Status: entities.CensusDraft}, deadline, onDueDay, entities.CensusDraft},
+		{"draft after the deadline", "club", &entities.Census{Year: 2025, Status: entities.CensusDraft}, deadline, afterDue, entities.CensusOverdue},
+		{"returned", "club", &entities.Census{Year: 2025, Status: entities.CensusReturned}, deadline, onDueDay, entities.CensusReturned},
+		{"returned after the deadline", "club", &entities.Census{Year: 2025, Status: entities.CensusReturned}, deadline, afterDue, entities.CensusReturned},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := censusStatus(tt.clubKey, 2025, tt.census, tt.deadline, tt.now)
+			if got.Status != tt.want {
+				t.Errorf("status = %s, want %s", got.Status, tt.want)
+			}
+		})
+	}
+
+	if got := censusStatus("late", 2025, nil, deadline, afterDue); got.Due == nil || !got.Due.Equal(deadline.Extensions["late"]) {
+		t.Errorf("due date should include the extension, got %v", got.Due)
+	}
+}
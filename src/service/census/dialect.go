@@ -0,0 +1,120 @@
+package census
+
+import (
+	"bytes"
+	"dpv/dpv/src/domain/dtos"
+	"dpv/dpv/src/repository/t"
+	"encoding/csv"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+)
+
+// Encodings reported for census CSV files
+const (
+	EncodingUTF8        = "utf-8"
+	EncodingUTF16       = "utf-16"
+	EncodingWindows1252 = "windows-1252"
+	EncodingISO88591    = "iso-8859-1"
+)
+
+// delimiters are the field separators recognised in CSV files, in order of preference.
+var delimiters = []rune{',', ';', '\t'}
+
+// sniffLines is the number of records inspected to detect the delimiter.
+const sniffLines = 20
+
+// readCSV transcodes a CSV file to UTF-8, detects its delimiter and returns its records
+// together with the detected dialect.
+func readCSV(content []byte) ([][]string, dtos.CensusFile, error) {
+	file := dtos.CensusFile{Format: FormatCSV}
+
+	text, err := decodeText(content, &file)
+	if err != nil {
+		return nil, file, err
+	}
+
+	delimiter := detectDelimiter(text)
+	file.Delimiter = string(delimiter)
+
+	reader := csv.NewReader(bytes.NewReader(text))
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1 // Rows with a wrong number of columns are reported per line by the validation
+	records, err := reader.ReadAll()
+	if err != nil {
+		// csv.ReadAll can return partial records on error
+		return nil, file, t.Errorf("failed to read CSV: %w", err)
+	}
+	return records, file, nil
+}
+
+// decodeText strips a byte order mark and transcodes the content to UTF-8. Files without
+// a BOM that are not valid UTF-8 are read as Windows-1252, a superset of ISO-8859-1.
+func decodeText(content []byte, file *dtos.CensusFile) ([]byte, error) {
+	var decoder *encoding.Decoder
+	switch {
+	case bytes.HasPrefix(content, []byte("\xef\xbb\xbf")):
+		file.BOM = true
+		file.Encoding = EncodingUTF8
+		content = content[3:]
+	case bytes.HasPrefix(content, []byte("\xff\xfe")), bytes.HasPrefix(content, []byte("\xfe\xff")):
+		file.BOM = true
+		file.Encoding = EncodingUTF16
+		decoder = unicode.UTF16(unicode.BigEndian, unicode.ExpectBOM).NewDecoder()
+	case utf8.Valid(content):
+		file.Encoding = EncodingUTF8
+	default:
+		// Bytes 0x80-0x9f are control characters in ISO-8859-1 but printable in Windows-1252
+		file.Encoding = EncodingISO88591
+		for _, b := range content {
+			if b >= 0x80 && b <= 0x9f {
+				file.Encoding = EncodingWindows1252
+				break
+			}
+		}
+		decoder = charmap.Windows1252.NewDecoder()
+	}
+
+	if decoder == nil {
+		return content, nil
+	}
+	decoded, err := decoder.Bytes(content)
+	if err != nil {
+		return nil, t.Errorf("failed to decode %s text: %w", file.Encoding, err)
+	}
+	return decoded, nil
+}
+
+// detectDelimiter picks the delimiter that splits the first records into the same number of
+// fields, preferring more fields. Comma is the default for single column or ambiguous files.
+func detectDelimiter(text []byte) rune {
+	best, bestFields, bestConsistent := delimiters[0], 1, false
+	for _, d := range delimiters {
+		reader := csv.NewReader(bytes.NewReader(text))
+		reader.Comma = d
+		reader.FieldsPerRecord = -1
+		reader.LazyQuotes = true
+
+		fields, consistent := 0, true
+		for i := 0; i < sniffLines; i++ {
+			record, err := reader.Read()
+			if err != nil {
+				break
+			}
+			if i == 0 {
+				fields = len(record)
+			} else if len(record) != fields {
+				consistent = false
+			}
+		}
+		if fields < 2 {
+			continue
+		}
+		if (consistent && !bestConsistent) || (consistent == bestConsistent && fields > bestFields) {
+			best, bestFields, bestConsistent = d, fields, consistent
+		}
+	}
+	return best
+}
@@ -0,0 +1,79 @@
+package census
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+)
+
+func TestReadCSVDialects(t *testing.T) {
+	latin1, _ := charmap.ISO8859_1.NewEncoder().String("Jörg;Müller;1990;m\nRenée;Weiß;1985;w\n")
+	cp1252, _ := charmap.Windows1252.NewEncoder().String("Zoë;O’Brien;1990;w\n")
+	utf16, _ := unicode.UTF16(unicode.LittleEndian, unicode.UseBOM).NewEncoder().String("Erika\tMustermann\t1990\tw\n")
+
+	tests := []struct {
+		name      string
+		content   string
+		delimiter string
+		encoding  string
+		bom       bool
+		first     string
+	}{
+		{"comma", "Erika,Mustermann,1990,w\n", ",", EncodingUTF8, false, "Erika"},
+		{"semicolon", "Vorname;Nachname;Jahrgang;Geschlecht\nErika;Muster,mann;1990;w\n", ";", EncodingUTF8, false, "Vorname"},
+		{"tab", "Erika\tMustermann\t1990\tw\n", "\t", EncodingUTF8, false, "Erika"},
+		{"quoted semicolons in comma file", "\"Erika;A\",Mustermann,1990,w\n", ",", EncodingUTF8, false, "Erika;A"},
+		{"utf-8 bom", "\xef\xbb\xbfJörg,Müller,1990,m\n", ",", EncodingUTF8, true, "Jörg"},
+		{"iso-8859-1", latin1, ";", EncodingISO88591, false, "Jörg"},
+		{"windows-1252", cp1252, ";", EncodingWindows1252, false, "Zoë"},
+		{"utf-16 bom", utf16, "\t", EncodingUTF16, true, "Erika"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, file, err := readCSV([]byte(tt.content))
+			if err != nil {
+				t.Fatalf("readCSV() error = %v", err)
+			}
+			if file.Format != FormatCSV || file.Delimiter != tt.delimiter || file.Encoding != tt.encoding || file.BOM != tt.bom {
+				t.Errorf("readCSV() dialect = %+v", file)
+			}
+			if len(records[0]) != 4 || records[0][0] != tt.first {
+				t.Errorf("readCSV() first record = %q", records[0])
+			}
+		})
+	}
+}
+
+func TestParseAndValidateReportsDialect(t *testing.T) {
+	s := &Service{}
+	upload, err := s.ParseAndValidate(bytes.NewReader([]byte("\xef\xbb\xbfVorname;Nachname;Jahrgang;Geschlecht\nErika;Mustermann;1990;w\n")), 2024, ParseOptions{})
+	if err != nil {
+		t.Fatalf("ParseAndValidate() error = %v", err)
+	}
+	if upload.MemberCount != 1 || upload.Members[0].Firstname != "Erika" {
+		t.Errorf("ParseAndValidate() census = %+v", upload.Census)
+	}
+	if upload.File.Delimiter != ";" || !upload.File.BOM || upload.File.Encoding != EncodingUTF8 {
+		t.Errorf("ParseAndValidate() file = %+v", upload.File)
+	}
+}
+
+func TestParseAndValidateReportsRaggedRows(t *testing.T) {
+	s := &Service{}
+	content := "Vorname;Nachname;Jahrgang;Geschlecht\nErika;Mustermann;1990;w\nMax;Mustermann;1985\nJörg;Weiß;1970;m;extra\n"
+	upload, err := s.ParseAndValidate(bytes.NewReader([]byte(content)), 2024, ParseOptions{})
+	if err == nil {
+		t.Fatal("rows with a wrong number of columns should fail the validation")
+	}
+	if upload == nil || len(upload.Problems) != 2 {
+		t.Fatalf("expected a problem for each ragged row, got %+v", upload)
+	}
+	for i, line := range []int{3, 4} {
+		if upload.Problems[i].Line != line || !strings.Contains(upload.Problems[i].Message, "columns") {
+			t.Errorf("problem %d = %+v, want a column count problem on line %d", i, upload.Problems[i], line)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package census
+
+import (
+	"context"
+	"dpv/dpv/src/domain/dtos"
+	"dpv/dpv/src/domain/entities"
+	"dpv/dpv/src/repository/dpv"
+	"dpv/dpv/src/repository/t"
+	"math"
+)
+
+// DefaultSwingThreshold is the relative change of the member count flagged for review, 0.5 meaning 50%.
+const DefaultSwingThreshold = 0.5
+
+// SwingThreshold returns the configured swing threshold, or the default one.
+func SwingThreshold() float64 {
+	if dpv.ConfigInstance != nil && dpv.ConfigInstance.Settings.CensusSwingThreshold > 0 {
+		return dpv.ConfigInstance.Settings.CensusSwingThreshold
+	}
+	return DefaultSwingThreshold
+}
+
+// Compare matches the members of a census against an earlier one by name and birth year. Members are
+// matched in passes of decreasing certainty: same name and birth year, same name with a corrected birth
+// year, a similar name with the same birth year, and a similar name with a birth year off by one.
+// The remaining members count as added or removed.
+func Compare(base, current *entities.Census, threshold float64) *dtos.CensusDiff {
+	diff := &dtos.CensusDiff{
+		Year:        current.Year,
+		BaseYear:    base.Year,
+		BaseCount:   len(base.Members),
+		MemberCount: len(current.Members),
+		Added:       []entities.MemberRow{},
+		Removed:     []entities.MemberRow{},
+		Corrections: []dtos.CensusMemberChange{},
+	}
+	diff.NetChange = diff.MemberCount - diff.BaseCount
+	if diff.BaseCount > 0 {
+		change := float64(diff.NetChange) / float64(diff.BaseCount)
+		percent := math.Round(change*1000) / 10
+		diff.ChangePercent = &percent
+		diff.Flagged = math.Abs(change) > threshold
+	}
+
+	baseNames := make([]string, len(base.Members))
+	for i, m := range base.Members {
+		baseNames[i] = memberName(m)
+	}
+	currentNames := make([]string, len(current.Members))
+	for i, m := range current.Members {
+		currentNames[i] = memberName(m)
+	}
+	baseMatched := make([]bool, len(base.Members))
+	currentMatched := make([]bool, len(current.Members))
+
+	passes := []func(i, j int) bool{
+		func(i, j int) bool {
+			return baseNames[i] == currentNames[j] && base.Members[i].BirthYear == current.Members[j].BirthYear
+		},
+		func(i, j int) bool {
+			return baseNames[i] == currentNames[j]
+		},
+		func(i, j int) bool {
+			return base.Members[i].BirthYear == current.Members[j].BirthYear && similarNames(baseNames[i], currentNames[j])
+		},
+		func(i, j int) bool {
+			return abs(base.Members[i].BirthYear-current.Members[j].BirthYear) <= 1 && similarNames(baseNames[i], currentNames[j])
+		},
+	}
+	for _, matches := range passes {
+		for j := range current.Members {
+			if currentMatched[j] {
+				continue
+			}
+			for i := range base.Members {
+				if baseMatched[i] || !matches(i, j) {
+					continue
+				}
+				baseMatched[i], currentMatched[j] = true, true
+				if fields := changedFields(base.Members[i], current.Members[j]); len(fields) > 0 {
+					diff.Corrections = append(diff.Corrections, dtos.CensusMemberChange{Before: base.Members[i], After: current.Members[j], Fields: fields})
+				} else {
+					diff.Unchanged++
+				}
+				break
+			}
+		}
+	}
+
+	for i, m := range base.Members {
+		if !baseMatched[i] {
+			diff.Removed = append(diff.Removed, m)
+		}
+	}
+	for j, m := range current.Members {
+		if !currentMatched[j] {
+			diff.Added = append(diff.Added, m)
+		}
+	}
+	return diff
+}
+
+// changedFields returns the census columns that differ between two matched members.
+// Differences in case, spacing or the spelling of umlauts are not a correction.
+func changedFields(before, after entities.MemberRow) []string {
+	var fields []string
+	if normalizeName(before.Firstname) != normalizeName(after.Firstname) {
+		fields = append(fields, ColumnFirstname)
+	}
+	if normalizeName(before.Lastname) != normalizeName(after.Lastname) {
+		fields = append(fields, ColumnLastname)
+	}
+	if before.BirthYear != after.BirthYear {
+		fields = append(fields, ColumnBirthyear)
+	}
+	return fields
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// Diff compares a club's census with the one of an earlier year (board members and admins).
+func (s *Service) Diff(ctx context.Context, clubKey string, year, baseYear int, user *entities.User) (*dtos.CensusDiff, error) {
+	if baseYear >= year {
+		return nil, t.Errorf("the base year must be before %d", year)
+	}
+	current, err := s.Get(ctx, clubKey, year, user)
+	if err != nil {
+		return nil, err
+	}
+	base, err := s.Db.GetCensus(ctx, clubKey, baseYear)
+	if err != nil {
+		return nil, err
+	}
+	diff := Compare(base, current, SwingThreshold())
+	diff.ClubKey = clubKey
+	return diff, nil
+}
+
+// Swings lists the clubs whose member count changed by more than the threshold since the previous
+// year (Admin only). Clubs without a census in the previous year are new and not listed.
+func (s *Service) Swings(ctx context.Context, year int, threshold float64) ([]dtos.CensusSwing, error) {
+	changes, err := s.Db.GetCensusMemberCountChanges(ctx, year)
+	if err != nil {
+		return nil, err
+	}
+	swings := []dtos.CensusSwing{}
+	for _, c := range changes {
+		if c.BaseCount == nil || *c.BaseCount == 0 {
+			continue
+		}
+		net := c.MemberCount - *c.BaseCount
+		change := float64(net) / float64(*c.BaseCount)
+		if math.Abs(change) <= threshold {
+			continue
+		}
+		swings = append(swings, dtos.CensusSwing{
+			ClubKey:       c.ClubKey,
+			ClubName:      c.Name,
+			Year:          year,
+			BaseCount:     *c.BaseCount,
+			MemberCount:   c.MemberCount,
+			NetChange:     net,
+			ChangePercent: math.Round(change*1000) / 10,
+		})
+	}
+	return swings, nil
+}
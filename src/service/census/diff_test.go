@@ -0,0 +1,88 @@
+package census
+
+import (
+	"dpv/dpv/src/domain/entities"
+	"testing"
+)
+
+func TestNormalizeName(t *testing.T) {
+	tests := map[string]string{
+		"Müller":        "mueller",
+		"  Jean-Luc  ":  "jean luc",
+		"José  Álvarez": "jose alvarez",
+		"Straße":        "strasse",
+		"O'Brien":       "o brien",
+		"ANNA":          "anna",
+	}
+	for in, want := range tests {
+		if got := normalizeName(in); got != want {
+			t.Errorf("normalizeName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestSimilarNames(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"anna schmidt", "anna schmitt", true},
+		{"mueller", "muller", true},
+		{"max meier", "max mayer", false},
+		{"jan", "jon", true},
+		{"jan", "tom", false},
+	}
+	for _, tt := range tests {
+		if got := similarNames(tt.a, tt.b); got != tt.want {
+			t.Errorf("similarNames(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	base := &entities.Census{Year: 2023, Members: []entities.MemberRow{
+		{Firstname: "Anna", Lastname: "Schmidt", BirthYear: 1990},
+		{Firstname: "Jörg", Lastname: "Müller", BirthYear: 1985},
+		{Firstname: "Max", Lastname: "Meier", BirthYear: 2001},
+		{Firstname: "Lena", Lastname: "Koch", BirthYear: 2010},
+	}}
+	current := &entities.Census{Year: 2024, Members: []entities.MemberRow{
+		{Firstname: "anna", Lastname: "schmidt", BirthYear: 1990},  // unchanged
+		{Firstname: "Joerg", Lastname: "Mueller", BirthYear: 1958}, // birth year corrected
+		{Firstname: "Max", Lastname: "Maier", BirthYear: 2002},     // name and birth year corrected
+		{Firstname: "Tim", Lastname: "Koch", BirthYear: 2012},      // added, Lena removed
+		{Firstname: "Ida", Lastname: "Wolf", BirthYear: 2015},      // added
+	}}
+	diff := Compare(base, current, DefaultSwingThreshold)
+
+	if diff.BaseCount != 4 || diff.MemberCount != 5 || diff.NetChange != 1 || diff.ChangePercent == nil || *diff.ChangePercent != 25 || diff.Flagged {
+		t.Errorf("Compare() counts = %+v", diff)
+	}
+	if diff.Unchanged != 1 || len(diff.Corrections) != 2 || len(diff.Added) != 2 || len(diff.Removed) != 1 {
+		t.Fatalf("Compare() = %d unchanged, %+v corrected, %+v added, %+v removed", diff.Unchanged, diff.Corrections, diff.Added, diff.Removed)
+	}
+	if c := diff.Corrections[0]; c.After.Lastname != "Mueller" || len(c.Fields) != 1 || c.Fields[0] != ColumnBirthyear {
+		t.Errorf("birth year correction = %+v", c)
+	}
+	if c := diff.Corrections[1]; c.Before.Lastname != "Meier" || len(c.Fields) != 2 || c.Fields[0] != ColumnLastname || c.Fields[1] != ColumnBirthyear {
+		t.Errorf("name correction = %+v", c)
+	}
+	if diff.Removed[0].Firstname != "Lena" {
+		t.Errorf("removed = %+v", diff.Removed)
+	}
+}
+
+func TestCompareFlagsSwings(t *testing.T) {
+	member := entities.MemberRow{Firstname: "Anna", Lastname: "Schmidt", BirthYear: 1990}
+	base := &entities.Census{Year: 2023, Members: []entities.MemberRow{member, member, member, member}}
+
+	if diff := Compare(base, &entities.Census{Year: 2024, Members: []entities.MemberRow{member}}, 0.5); !diff.Flagged || *diff.ChangePercent != -75 {
+		t.Errorf("Compare() drop = %+v, want flagged", diff)
+	}
+	if diff := Compare(base, &entities.Census{Year: 2024, Members: []entities.MemberRow{member, member}}, 0.5); diff.Flagged {
+		t.Errorf("Compare() 50%% drop flagged, want only changes above the threshold")
+	}
+	if diff := Compare(&entities.Census{Year: 2023}, base, 0.5); diff.Flagged || diff.ChangePercent != nil {
+		t.Errorf("Compare() from empty census = %+v, want not flagged", diff)
+	}
+}
@@ -0,0 +1,143 @@
+package census
+
+import (
+	"context"
+	"dpv/dpv/src/domain/dtos"
+	"dpv/dpv/src/domain/entities"
+	"dpv/dpv/src/repository/t"
+	"sort"
+)
+
+// duplicateGroup are census rows that are likely the same person.
+type duplicateGroup struct {
+	rows  []int // indices into the members, in their order
+	exact bool  // all rows have the same normalised name
+}
+
+// findDuplicates groups members that are likely the same person: rows with the same birth year and
+// normalised name, unless both have different birth dates, and rows with the same full birth date whose
+// names are similar or have first and last name swapped. Similar names alone are not enough, siblings
+// often share a birth year. Only groups of at least two rows are returned, ordered by their first row.
+func findDuplicates(members []entities.MemberRow) []duplicateGroup {
+	type cluster struct {
+		name, swapped, date string
+		group               duplicateGroup
+	}
+	type exactKey struct {
+		year int
+		name string
+	}
+	// Rows are only compared within their bucket, keeping large federations linear
+	byName := make(map[exactKey][]*cluster)
+	byDate := make(map[string][]*cluster)
+	var clusters []*cluster
+	for i, m := range members {
+		name := memberName(m)
+		var match *cluster
+		for _, c := range byName[exactKey{m.BirthYear, name}] {
+			if c.date == "" || m.BirthDate == "" || c.date == m.BirthDate {
+				match = c
+				break
+			}
+		}
+		if match == nil && m.BirthDate != "" {
+			for _, c := range byDate[m.BirthDate] {
+				if c.swapped == name || similarNames(c.name, name) {
+					match = c
+					break
+				}
+			}
+		}
+		if match == nil {
+			match = &cluster{
+				name:    name,
+				swapped: normalizeName(m.Lastname + " " + m.Firstname),
+				group:   duplicateGroup{exact: true},
+			}
+			byName[exactKey{m.BirthYear, name}] = append(byName[exactKey{m.BirthYear, name}], match)
+			clusters = append(clusters, match)
+		}
+		if match.date == "" && m.BirthDate != "" {
+			match.date = m.BirthDate
+			byDate[m.BirthDate] = append(byDate[m.BirthDate], match)
+		}
+		match.group.rows = append(match.group.rows, i)
+		match.group.exact = match.group.exact && match.name == name
+	}
+
+	var groups []duplicateGroup
+	for _, c := range clusters {
+		if len(c.group.rows) > 1 {
+			groups = append(groups, c.group)
+		}
+	}
+	return groups
+}
+
+// warnDuplicates reports rows that repeat an earlier row of the file. lines are the line
+// numbers of the members.
+func warnDuplicates(members []entities.MemberRow, lines []int, r *report) {
+	for _, group := range findDuplicates(members) {
+		first := group.rows[0]
+		for _, i := range group.rows[1:] {
+			if memberName(members[i]) == memberName(members[first]) {
+				r.warnAt(lines[i], 0, "", t.Errorf("line %d: duplicate of line %d", lines[i], lines[first]))
+			} else {
+				r.warnAt(lines[i], 0, "", t.Errorf("line %d: possibly the same person as line %d", lines[i], lines[first]))
+			}
+		}
+	}
+}
+
+// Duplicates lists persons counted in the censuses of several clubs in a year (Admin only).
+// Persons are matched like duplicates within a file, see findDuplicates.
+func (s *Service) Duplicates(ctx context.Context, year int) ([]dtos.CensusDuplicate, error) {
+	members, err := s.Db.GetCensusMembers(ctx, year)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]dtos.CensusMemberEntry, len(members))
+	for i, m := range members {
+		entries[i] = dtos.CensusMemberEntry{ClubKey: m.ClubKey, ClubName: m.ClubName, MemberRow: m.MemberRow}
+	}
+	return crossClubDuplicates(entries), nil
+}
+
+// crossClubDuplicates returns the duplicate groups spanning at least two clubs, sorted by name.
+func crossClubDuplicates(entries []dtos.CensusMemberEntry) []dtos.CensusDuplicate {
+	members := make([]entities.MemberRow, len(entries))
+	for i, e := range entries {
+		members[i] = e.MemberRow
+	}
+
+	result := []dtos.CensusDuplicate{}
+	for _, group := range findDuplicates(members) {
+		clubs := make(map[string]bool)
+		duplicate := dtos.CensusDuplicate{Exact: group.exact}
+		for _, i := range group.rows {
+			clubs[entries[i].ClubKey] = true
+			duplicate.Entries = append(duplicate.Entries, entries[i])
+		}
+		if len(clubs) < 2 {
+			continue
+		}
+		first := entries[group.rows[0]]
+		duplicate.Firstname, duplicate.Lastname, duplicate.BirthYear = first.Firstname, first.Lastname, first.BirthYear
+		for _, i := range group.rows {
+			if entries[i].BirthDate != "" {
+				duplicate.BirthDate = entries[i].BirthDate
+				break
+			}
+		}
+		duplicate.Clubs = len(clubs)
+		result = append(result, duplicate)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := normalizeName(result[i].Lastname+" "+result[i].Firstname), normalizeName(result[j].Lastname+" "+result[j].Firstname)
+		if a != b {
+			return a < b
+		}
+		return result[i].BirthYear < result[j].BirthYear
+	})
+	return result
+}
@@ -0,0 +1,99 @@
+package census
+
+import (
+	"dpv/dpv/src/domain/dtos"
+	"dpv/dpv/src/domain/entities"
+	"strings"
+	"testing"
+)
+
+func TestFindDuplicates(t *testing.T) {
+	members := []entities.MemberRow{
+		{Firstname: "Anna", Lastname: "Schmidt", BirthYear: 1990},
+		{Firstname: "Jörg", Lastname: "Müller", BirthYear: 1985, BirthDate: "1985-04-12"},
+		{Firstname: "ANNA", Lastname: "Schmidt ", BirthYear: 1990},                        // exact
+		{Firstname: "Anna", Lastname: "Schmidt", BirthYear: 1991},                         // other birth year
+		{Firstname: "Joerg", Lastname: "Muler", BirthYear: 1985, BirthDate: "1985-04-12"}, // typo
+		{Firstname: "Müller", Lastname: "Jörg", BirthYear: 1985, BirthDate: "1985-04-12"}, // swapped
+		{Firstname: "Max", Lastname: "Meier", BirthYear: 2001},
+		{Firstname: "Lena", Lastname: "Koch", BirthYear: 2012}, // sibling without birth date
+		{Firstname: "Lina", Lastname: "Koch", BirthYear: 2012}, // sibling without birth date
+		{Firstname: "Tom", Lastname: "Wolf", BirthYear: 2010, BirthDate: "2010-01-05"},
+		{Firstname: "Tom", Lastname: "Wolf", BirthYear: 2010, BirthDate: "2010-11-30"},    // same name, other person
+		{Firstname: "Jörg", Lastname: "Müller", BirthYear: 1985, BirthDate: "1985-09-01"}, // same name, other person
+	}
+	groups := findDuplicates(members)
+	if len(groups) != 2 {
+		t.Fatalf("findDuplicates() = %+v, want 2 groups", groups)
+	}
+	if g := groups[0]; !g.exact || len(g.rows) != 2 || g.rows[0] != 0 || g.rows[1] != 2 {
+		t.Errorf("exact group = %+v", g)
+	}
+	if g := groups[1]; g.exact || len(g.rows) != 3 || g.rows[0] != 1 || g.rows[1] != 4 || g.rows[2] != 5 {
+		t.Errorf("near group = %+v", g)
+	}
+}
+
+func TestValidateRecordsWarnsDuplicates(t *testing.T) {
+	records := [][]string{
+		{"Firstname", "Lastname", "Birthyear", "Gender", "Geburtsdatum"},
+		{"Anna", "Schmidt", "1990", "f", "03.05.1990"},
+		{"Anna", "Schmidt", "1990", "f", ""},
+		{"Ana", "Schmidt", "1990", "f", "1990-05-03"},
+		{"Hanna", "Schmidt", "1990", "f", ""},
+	}
+	census, r := validateRecords(records, 2024, nil)
+	if census == nil || census.MemberCount != 4 || r.errors() != 0 {
+		t.Fatalf("validateRecords() = %+v, %+v", census, r)
+	}
+	if census.Members[0].BirthDate != "1990-05-03" || census.Members[1].BirthDate != "" {
+		t.Errorf("validateRecords() birth dates = %+v", census.Members)
+	}
+	if len(r) != 2 || r[0].line != 3 || r[1].line != 4 || r[0].severity != SeverityWarning {
+		t.Fatalf("validateRecords() problems = %+v", r)
+	}
+	if msg := r[0].err.Error(); !strings.Contains(msg, "duplicate of line 2") {
+		t.Errorf("exact duplicate = %q", msg)
+	}
+	if msg := r[1].err.Error(); !strings.Contains(msg, "same person as line 2") {
+		t.Errorf("near duplicate = %q", msg)
+	}
+}
+
+func TestValidateRecordsBirthDate(t *testing.T) {
+	for _, tt := range []struct{ date, errMsg string }{
+		{"31.02.1990", "invalid birth date"},
+		{"1991-01-01", "does not match birth year 1990"},
+	} {
+		records := [][]string{{"Vorname", "Nachname", "Geburtsjahr", "Geschlecht", "Geburtsdatum"}, {"Anna", "Schmidt", "1990", "w", tt.date}}
+		if _, r := validateRecords(records, 2024, nil); r.firstError() == nil || !strings.Contains(r.firstError().Error(), tt.errMsg) {
+			t.Errorf("validateRecords(%q) error = %v, want %q", tt.date, r.firstError(), tt.errMsg)
+		}
+	}
+}
+
+func TestCrossClubDuplicates(t *testing.T) {
+	entry := func(club, first, last string, year int, date string) dtos.CensusMemberEntry {
+		return dtos.CensusMemberEntry{ClubKey: club, MemberRow: entities.MemberRow{Firstname: first, Lastname: last, BirthYear: year, BirthDate: date}}
+	}
+	entries := []dtos.CensusMemberEntry{
+		entry("a", "Max", "Meier", 2001, ""),
+		entry("a", "Max", "Meier", 2001, ""), // same club only
+		entry("a", "Lena", "Koch", 2010, ""),
+		entry("b", "Lena", "Koch", 2010, "2010-06-01"),
+		entry("c", "Lena", "Koh", 2010, "2010-06-01"),
+		entry("d", "Lina", "Koch", 2010, ""), // similar name without birth date
+		entry("b", "Anna", "Berg", 1990, ""),
+		entry("c", "Anna", "Berg", 1990, ""),
+	}
+	duplicates := crossClubDuplicates(entries)
+	if len(duplicates) != 2 {
+		t.Fatalf("crossClubDuplicates() = %+v, want 2", duplicates)
+	}
+	if d := duplicates[0]; d.Lastname != "Berg" || !d.Exact || d.Clubs != 2 || len(d.Entries) != 2 {
+		t.Errorf("first duplicate = %+v", d)
+	}
+	if d := duplicates[1]; d.Lastname != "Koch" || d.Exact || d.Clubs != 3 || len(d.Entries) != 3 || d.BirthDate != "2010-06-01" {
+		t.Errorf("second duplicate = %+v", d)
+	}
+}
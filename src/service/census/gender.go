@@ -0,0 +1,90 @@
+package census
+
+import (
+	"context"
+	"dpv/dpv/src/domain/dtos"
+	"dpv/dpv/src/domain/entities"
+	"dpv/dpv/src/repository/t"
+	"strings"
+)
+
+// genderSynonyms are common spellings besides the canonical value and its translations.
+var genderSynonyms = map[string][]string{
+	entities.GenderFemale:      {"f", "w", "woman", "girl", "weiblich", "frau", "mädchen"},
+	entities.GenderMale:        {"m", "man", "boy", "männlich", "mann", "junge"},
+	entities.GenderDiverse:     {"d", "x", "divers", "other", "non-binary", "nonbinary", "nb"},
+	entities.GenderUnspecified: {"", "-", "u", "unknown", "not specified", "unbekannt", "keine angabe", "ka", "ohne angabe"},
+}
+
+// genderLabels are the translatable names of the canonical genders, recognised in all languages.
+var genderLabels = map[string]error{
+	entities.GenderFemale:      t.Errorf("female"),
+	entities.GenderMale:        t.Errorf("male"),
+	entities.GenderDiverse:     t.Errorf("diverse"),
+	entities.GenderUnspecified: t.Errorf("unspecified"),
+}
+
+// acceptedGenders lists the accepted inputs in the error message, translated per language.
+var acceptedGenders = t.Errorf("female (f, w), male (m), diverse (d, x) or unspecified (empty)")
+
+// genderAliases returns the normalised spellings of all canonical genders.
+func genderAliases() map[string]string {
+	aliases := make(map[string]string)
+	for _, gender := range entities.Genders {
+		for _, synonym := range genderSynonyms[gender] {
+			aliases[normalizeGender(synonym)] = gender
+		}
+		for _, translation := range t.Translations(genderLabels[gender].Error()) {
+			aliases[normalizeGender(translation)] = gender
+		}
+	}
+	return aliases
+}
+
+// normalizeGender lowercases a spelling and drops dots, so "K.A." matches "ka".
+func normalizeGender(s string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(s)), ".", "")
+}
+
+// NormalizeGender returns the canonical gender for a spelling in any supported language.
+// An empty value is unspecified.
+func NormalizeGender(s string) (string, bool) {
+	gender, ok := genderAliases()[normalizeGender(s)]
+	return gender, ok
+}
+
+// NormalizeGenders rewrites the genders of all stored censuses to their canonical values (Admin only).
+// Unknown values are kept and reported. Running it again does not change anything.
+func (s *Service) NormalizeGenders(ctx context.Context) (*dtos.GenderMigration, error) {
+	censuses, err := s.Db.GetAllCensuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	aliases := genderAliases()
+	result := &dtos.GenderMigration{Unknown: map[string]int{}}
+	for i := range censuses {
+		census := &censuses[i]
+		result.Censuses++
+		changed := 0
+		for j, member := range census.Members {
+			gender, ok := aliases[normalizeGender(member.Gender)]
+			if !ok {
+				result.Unknown[member.Gender]++
+				continue
+			}
+			if gender != member.Gender {
+				census.Members[j].Gender = gender
+				changed++
+			}
+		}
+		if changed == 0 {
+			continue
+		}
+		if err := s.Db.Censuses.Update(census, ctx); err != nil {
+			return nil, t.Errorf("failed to update census %s: %w", census.GetKey(), err)
+		}
+		result.Updated++
+		result.Members += changed
+	}
+	return result, nil
+}
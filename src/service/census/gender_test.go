@@ -0,0 +1,56 @@
+package census
+
+import (
+	"dpv/dpv/src/domain/entities"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeGender(t *testing.T) {
+	tests := map[string]string{
+		"w":            entities.GenderFemale,
+		"F":            entities.GenderFemale,
+		" Weiblich ":   entities.GenderFemale,
+		"female":       entities.GenderFemale,
+		"m":            entities.GenderMale,
+		"Männlich":     entities.GenderMale,
+		"divers":       entities.GenderDiverse,
+		"X":            entities.GenderDiverse,
+		"":             entities.GenderUnspecified,
+		"k.A.":         entities.GenderUnspecified,
+		"unspecified":  entities.GenderUnspecified,
+		"Keine Angabe": entities.GenderUnspecified,
+	}
+	for input, want := range tests {
+		if got, ok := NormalizeGender(input); !ok || got != want {
+			t.Errorf("NormalizeGender(%q) = %q, %v, want %q", input, got, ok, want)
+		}
+	}
+	if got, ok := NormalizeGender("q"); ok {
+		t.Errorf("NormalizeGender(\"q\") = %q, want unknown", got)
+	}
+}
+
+func TestValidateRecordsGender(t *testing.T) {
+	census, r := validateRecords([][]string{
+		{"Erika", "Mustermann", "1990", "weiblich"},
+		{"Max", "Mustermann", "1985", "M"},
+		{"Kim", "Mustermann", "1995", ""},
+		{"Alex", "Mustermann", "1980", "q"},
+	}, 2024, nil)
+	if r.errors() != 1 || r[0].line != 4 || r[0].field != ColumnGender {
+		t.Fatalf("validateRecords() report = %+v", r)
+	}
+	if msg := r[0].err.Error(); !strings.Contains(msg, "unknown gender 'q'") || !strings.Contains(msg, "diverse (d, x)") {
+		t.Errorf("validateRecords() message = %q", msg)
+	}
+	want := []string{entities.GenderFemale, entities.GenderMale, entities.GenderUnspecified}
+	if len(census.Members) != len(want) {
+		t.Fatalf("validateRecords() members = %+v", census.Members)
+	}
+	for i, m := range census.Members {
+		if m.Gender != want[i] {
+			t.Errorf("member %d gender = %q, want %q", i, m.Gender, want[i])
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package census
+
+import (
+	"dpv/dpv/src/domain/entities"
+	"dpv/dpv/src/service/membership"
+	"strings"
+	"unicode"
+)
+
+// normalizeName lowercases a name, transliterates it like the membership cards, so "Müller" and
+// "Mueller" are the same, and reduces everything but letters to single spaces.
+func normalizeName(s string) string {
+	s = membership.Transliterate(strings.ToLower(s))
+	return strings.Join(strings.FieldsFunc(s, func(r rune) bool { return !unicode.IsLetter(r) }), " ")
+}
+
+// memberName returns the normalised full name of a member.
+func memberName(m entities.MemberRow) string {
+	return normalizeName(m.Firstname + " " + m.Lastname)
+}
+
+// levenshtein returns the edit distance between two strings in runes.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// similarNames reports whether two normalised names differ by at most one typo per five letters.
+func similarNames(a, b string) bool {
+	if a == b {
+		return true
+	}
+	la, lb := len([]rune(a)), len([]rune(b))
+	limit := max(1, min(la, lb)/5)
+	if abs(la-lb) > limit {
+		return false
+	}
+	return levenshtein(a, b) <= limit
+}
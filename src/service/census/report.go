@@ -0,0 +1,72 @@
+package census
+
+import (
+	"dpv/dpv/src/domain/dtos"
+	"dpv/dpv/src/repository/t"
+)
+
+// Severities of problems found in census files. Errors reject the file, warnings are informational.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// problem is a finding in a census file, translated when the report is returned.
+type problem struct {
+	line     int // 1-based row, 0 for the whole file
+	column   int // 1-based column, 0 for the whole row
+	field    string
+	severity string
+	err      error
+}
+
+// report collects all problems of a census file instead of stopping at the first one.
+type report []problem
+
+func (r *report) add(severity string, line, column int, field string, err error) {
+	*r = append(*r, problem{line: line, column: column, field: field, severity: severity, err: err})
+}
+
+func (r *report) errorAt(line, column int, field string, err error) {
+	r.add(SeverityError, line, column, field, err)
+}
+
+func (r *report) warnAt(line, column int, field string, err error) {
+	r.add(SeverityWarning, line, column, field, err)
+}
+
+// errors returns the number of problems with error severity.
+func (r report) errors() int {
+	n := 0
+	for _, p := range r {
+		if p.severity == SeverityError {
+			n++
+		}
+	}
+	return n
+}
+
+// firstError returns the first problem with error severity, or nil.
+func (r report) firstError() error {
+	for _, p := range r {
+		if p.severity == SeverityError {
+			return p.err
+		}
+	}
+	return nil
+}
+
+// translate returns the problems with messages in the given language.
+func (r report) translate(lang string) []dtos.CensusProblem {
+	problems := make([]dtos.CensusProblem, 0, len(r))
+	for _, p := range r {
+		problems = append(problems, dtos.CensusProblem{
+			Line:     p.line,
+			Column:   p.column,
+			Field:    p.field,
+			Severity: p.severity,
+			Message:  t.T(p.err, lang),
+		})
+	}
+	return problems
+}
@@ -0,0 +1,60 @@
+package census
+
+import (
+	"bytes"
+	"dpv/dpv/src/domain/dtos"
+	"testing"
+)
+
+func TestParseAndValidateReportsAllProblems(t *testing.T) {
+	s := &Service{}
+	csv := "Vorname;Nachname;Geburtsjahr;Geschlecht;Verein\n" +
+		"Erika;Mustermann;1990;w;DPV\n" +
+		"123;Mustermann;abc;w;DPV\n" +
+		"Max;Mustermann;2023;m;DPV\n" +
+		"Oma;Mustermann;1920;w;DPV\n" +
+		" ; ; ; ; \n"
+
+	upload, err := s.ParseAndValidate(bytes.NewReader([]byte(csv)), 2024, ParseOptions{})
+	if err == nil || err.Error() != "census file has 4 errors" {
+		t.Fatalf("ParseAndValidate() error = %v", err)
+	}
+	if upload == nil || upload.Census != nil {
+		t.Fatalf("ParseAndValidate() upload = %+v, want problems without census", upload)
+	}
+
+	want := []dtos.CensusProblem{
+		{Line: 1, Column: 5, Severity: SeverityWarning, Message: "column 5 'Verein' is not a census column and was ignored"},
+		{Line: 3, Column: 1, Field: ColumnFirstname, Severity: SeverityError, Message: "line 3: Firstname contains only numbers"},
+		{Line: 3, Column: 3, Field: ColumnBirthyear, Severity: SeverityError, Message: "line 3: invalid birth year 'abc'"},
+		{Line: 4, Column: 3, Field: ColumnBirthyear, Severity: SeverityError, Message: "line 4: age 1 is too young (minimum 2 years)"},
+		{Line: 5, Column: 3, Field: ColumnBirthyear, Severity: SeverityWarning, Message: "line 5: age 104 is unusually high, please check the birth year"},
+		{Line: 6, Severity: SeverityError, Message: "line 6: blank row found"},
+	}
+	if len(upload.Problems) != len(want) {
+		t.Fatalf("ParseAndValidate() problems = %+v", upload.Problems)
+	}
+	for i, p := range upload.Problems {
+		if p != want[i] {
+			t.Errorf("problem %d = %+v, want %+v", i, p, want[i])
+		}
+	}
+}
+
+func TestParseAndValidateWarningsOnly(t *testing.T) {
+	s := &Service{}
+	upload, err := s.ParseAndValidate(bytes.NewReader([]byte("Erika,Mustermann,1920,w\nMax,Mustermann,1985,m\n")), 2024, ParseOptions{})
+	if err != nil {
+		t.Fatalf("ParseAndValidate() error = %v", err)
+	}
+	if upload.MemberCount != 2 || len(upload.Problems) != 1 || upload.Problems[0].Severity != SeverityWarning {
+		t.Errorf("ParseAndValidate() = %+v, problems %+v", upload.Census, upload.Problems)
+	}
+}
+
+func TestValidateRecordsMissingColumns(t *testing.T) {
+	_, r := validateRecords([][]string{{"Vorname", "Nachname", "Ort"}, {"Erika", "Mustermann", "Berlin"}}, 2024, nil)
+	if r.errors() != 2 || r[0].field != ColumnBirthyear || r[1].field != ColumnGender {
+		t.Errorf("validateRecords() report = %+v", r)
+	}
+}
@@ -0,0 +1,299 @@
+package census
+
+import (
+	"archive/zip"
+	"bytes"
+	"dpv/dpv/src/repository/t"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Census file formats, detected by content
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+	FormatODS  = "ods"
+)
+
+const odsMimeType = "application/vnd.oasis.opendocument.spreadsheet"
+
+// maxRepeat caps repeated empty cells and rows in ODS files, which LibreOffice uses to pad sheets to their full size.
+const maxRepeat = 1000
+
+// detectFormat tells spreadsheets (zip containers) from CSV by their content.
+func detectFormat(content []byte) (string, error) {
+	if !bytes.HasPrefix(content, []byte("PK\x03\x04")) {
+		return FormatCSV, nil
+	}
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return "", t.Errorf("failed to read spreadsheet: %w", err)
+	}
+	for _, f := range archive.File {
+		switch f.Name {
+		case "xl/workbook.xml":
+			return FormatXLSX, nil
+		case "mimetype":
+			rc, err := f.Open()
+			if err != nil {
+				return "", t.Errorf("failed to read spreadsheet: %w", err)
+			}
+			mime, _ := io.ReadAll(io.LimitReader(rc, 100))
+			rc.Close()
+			if strings.TrimSpace(string(mime)) == odsMimeType {
+				return FormatODS, nil
+			}
+		}
+	}
+	return "", t.Errorf("unsupported file format, please upload CSV, XLSX or ODS")
+}
+
+// readXLSX returns the rows and name of the named sheet, or of the first sheet if no name is given.
+func readXLSX(content []byte, sheet string) ([][]string, string, error) {
+	f, err := excelize.OpenReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, "", t.Errorf("failed to read spreadsheet: %w", err)
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	if sheet == "" {
+		if len(sheets) == 0 {
+			return nil, "", t.Errorf("spreadsheet has no sheets")
+		}
+		sheet = sheets[0]
+	} else if idx, _ := f.GetSheetIndex(sheet); idx < 0 {
+		return nil, "", t.Errorf("sheet '%s' not found", sheet)
+	}
+	rows, err := f.GetRows(sheet)
+	if err != nil {
+		return nil, "", t.Errorf("failed to read sheet '%s': %w", sheet, err)
+	}
+	return rows, sheet, nil
+}
+
+// odsCell is a table cell of an OpenDocument spreadsheet.
+type odsCell struct {
+	Repeated   int            `xml:"number-columns-repeated,attr"`
+	ValueType  string         `xml:"value-type,attr"`
+	Value      string         `xml:"value,attr"`
+	Paragraphs []odsParagraph `xml:"p"`
+}
+
+// odsParagraph is the text of a paragraph, including the text of formatted spans and links.
+// Runs of spaces, tabs and line breaks are stored as elements (<text:s text:c="2"/>).
+type odsParagraph string
+
+func (p *odsParagraph) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var text strings.Builder
+	depth := 0
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch el := token.(type) {
+		case xml.CharData:
+			text.Write(el)
+		case xml.StartElement:
+			switch el.Name.Local {
+			case "annotation": // Cell comments are not part of the value
+				if err := d.Skip(); err != nil {
+					return err
+				}
+				continue
+			case "s":
+				text.WriteString(strings.Repeat(" ", odsSpaces(el)))
+			case "tab":
+				text.WriteString("\t")
+			case "line-break":
+				text.WriteString("\n")
+			}
+			depth++
+		case xml.EndElement:
+			if depth == 0 {
+				*p = odsParagraph(text.String())
+				return nil
+			}
+			depth--
+		}
+	}
+}
+
+// odsSpaces returns the number of spaces a <text:s> element stands for.
+func odsSpaces(el xml.StartElement) int {
+	for _, attr := range el.Attr {
+		if attr.Name.Local == "c" {
+			if n, err := strconv.Atoi(attr.Value); err == nil && n > 0 {
+				return min(n, maxRepeat)
+			}
+		}
+	}
+	return 1
+}
+
+type odsRow struct {
+	Repeated int       `xml:"number-rows-repeated,attr"`
+	Cells    []odsCell `xml:",any"`
+}
+
+// readODS returns the rows and name of the named sheet, or of the first sheet if no name is given.
+func readODS(content []byte, sheet string) ([][]string, string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, "", t.Errorf("failed to read spreadsheet: %w", err)
+	}
+	rc, err := archive.Open("content.xml")
+	if err != nil {
+		return nil, "", t.Errorf("failed to read spreadsheet: %w", err)
+	}
+	defer rc.Close()
+
+	decoder := xml.NewDecoder(rc)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, "", t.Errorf("failed to read spreadsheet: %w", err)
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "table" {
+			continue
+		}
+		name := tableName(start)
+		if sheet != "" && name != sheet {
+			if err := decoder.Skip(); err != nil {
+				return nil, "", t.Errorf("failed to read spreadsheet: %w", err)
+			}
+			continue
+		}
+		rows, err := readODSTable(decoder)
+		return rows, name, err
+	}
+	if sheet != "" {
+		return nil, "", t.Errorf("sheet '%s' not found", sheet)
+	}
+	return nil, "", t.Errorf("spreadsheet has no sheets")
+}
+
+func tableName(start xml.StartElement) string {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "name" {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// readODSTable reads the rows of the table the decoder is positioned in, including rows nested in row groups.
+func readODSTable(decoder *xml.Decoder) ([][]string, error) {
+	var rows [][]string
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, t.Errorf("failed to read spreadsheet: %w", err)
+		}
+		switch el := token.(type) {
+		case xml.EndElement:
+			if el.Name.Local == "table" {
+				return trimTrailingEmpty(rows), nil
+			}
+		case xml.StartElement:
+			if el.Name.Local != "table-row" {
+				continue
+			}
+			var row odsRow
+			if err := decoder.DecodeElement(&row, &el); err != nil {
+				return nil, t.Errorf("failed to read spreadsheet: %w", err)
+			}
+			values := odsRowValues(row)
+			for i := 0; i < min(max(row.Repeated, 1), maxRepeat); i++ {
+				rows = append(rows, values)
+			}
+		}
+	}
+}
+
+func odsRowValues(row odsRow) []string {
+	var values []string
+	for _, cell := range row.Cells {
+		paragraphs := make([]string, len(cell.Paragraphs))
+		for i, p := range cell.Paragraphs {
+			paragraphs[i] = string(p)
+		}
+		value := strings.Join(paragraphs, "\n")
+		if value == "" && cell.ValueType == "float" {
+			value = cell.Value
+		}
+		for i := 0; i < min(max(cell.Repeated, 1), maxRepeat); i++ {
+			values = append(values, value)
+		}
+	}
+	// Drop the padding cells up to the end of the sheet
+	for len(values) > 0 && strings.TrimSpace(values[len(values)-1]) == "" {
+		values = values[:len(values)-1]
+	}
+	return values
+}
+
+// trimTrailingEmpty removes empty rows at the end of a sheet.
+func trimTrailingEmpty(rows [][]string) [][]string {
+	for len(rows) > 0 && isRowEmpty(rows[len(rows)-1]) {
+		rows = rows[:len(rows)-1]
+	}
+	return rows
+}
+
+// normalizeSpreadsheetRows pads rows to the width of the first row, since spreadsheets
+// omit empty cells at the end of a row, and drops empty rows at the end of the sheet.
+func normalizeSpreadsheetRows(rows [][]string) [][]string {
+	rows = trimTrailingEmpty(rows)
+	if len(rows) == 0 {
+		return rows
+	}
+	width := len(rows[0])
+	for i, row := range rows {
+		if len(row) < width {
+			rows[i] = append(row, make([]string, width-len(row))...)
+		}
+	}
+	return rows
+}
+
+// GenerateSampleXLSX returns the sample census as an Excel workbook with localized headers and entries.
+func (s *Service) GenerateSampleXLSX(lang string) ([]byte, error) {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	sheet := f.GetSheetName(0)
+	rows := [][]string{
+		strings.Split(t.T(t.Errorf("Firstname,Lastname,Birthyear,Gender"), lang), ","),
+		strings.Split(t.T(t.Errorf("Jane,Doe,1990,female"), lang), ","),
+		strings.Split(t.T(t.Errorf("John,Smith,1985,male"), lang), ","),
+	}
+	for i, row := range rows {
+		values := make([]interface{}, len(row))
+		for j, v := range row {
+			if n, err := strconv.Atoi(v); err == nil {
+				values[j] = n
+			} else {
+				values[j] = v
+			}
+		}
+		cell, _ := excelize.CoordinatesToCellName(1, i+1)
+		if err := f.SetSheetRow(sheet, cell, &values); err != nil {
+			return nil, t.Errorf("failed to create sample workbook: %w", err)
+		}
+	}
+
+	var buffer bytes.Buffer
+	if err := f.Write(&buffer); err != nil {
+		return nil, t.Errorf("failed to create sample workbook: %w", err)
+	}
+	return buffer.Bytes(), nil
+}
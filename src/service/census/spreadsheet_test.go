@@ -0,0 +1,157 @@
+package census
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func xlsxFile(t *testing.T, sheets map[string][][]interface{}, order []string) []byte {
+	t.Helper()
+	f := excelize.NewFile()
+	defer f.Close()
+	f.SetSheetName(f.GetSheetName(0), order[0])
+	for _, name := range order[1:] {
+		if _, err := f.NewSheet(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for name, rows := range sheets {
+		for i, row := range rows {
+			cell, _ := excelize.CoordinatesToCellName(1, i+1)
+			if err := f.SetSheetRow(name, cell, &row); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	var buf bytes.Buffer
+	if err := f.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func odsFile(t *testing.T, contentXML string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	m, _ := w.Create("mimetype")
+	m.Write([]byte(odsMimeType))
+	c, _ := w.Create("content.xml")
+	c.Write([]byte(contentXML))
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+const odsContent = `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0" xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0">
+<office:body><office:spreadsheet>
+<table:table table:name="Notizen"><table:table-row><table:table-cell><text:p>nichts</text:p></table:table-cell></table:table-row></table:table>
+<table:table table:name="Mitglieder">
+<table:table-row><table:table-cell><text:p>Vorname</text:p></table:table-cell><table:table-cell><text:p>Nachname</text:p></table:table-cell><table:table-cell><text:p>Geburtsjahr</text:p></table:table-cell><table:table-cell><text:p>Geschlecht</text:p></table:table-cell><table:table-cell table:number-columns-repeated="1020"/></table:table-row>
+<table:table-row><table:table-cell><text:p>Jörg</text:p></table:table-cell><table:table-cell><text:p>Weiß</text:p></table:table-cell><table:table-cell office:value-type="float" office:value="1990"/><table:table-cell><text:p>m</text:p></table:table-cell></table:table-row>
+<table:table-row-group><table:table-row><table:table-cell><text:p>Erika</text:p></table:table-cell><table:table-cell><text:p>Mustermann</text:p></table:table-cell><table:table-cell office:value-type="float" office:value="1985"><text:p>1985</text:p></table:table-cell><table:table-cell><text:p>w</text:p></table:table-cell></table:table-row></table:table-row-group>
+<table:table-row table:number-rows-repeated="1048570"><table:table-cell table:number-columns-repeated="1024"/></table:table-row>
+</table:table>
+</office:spreadsheet></office:body></office:document-content>`
+
+func TestParseAndValidateSpreadsheets(t *testing.T) {
+	s := &Service{}
+	members := [][]interface{}{
+		{"Vorname", "Nachname", "Geburtsjahr", "Geschlecht"},
+		{"Erika", "Mustermann", 1990, "w"},
+		{"Max", "Mustermann", 1985, "m"},
+	}
+
+	xlsx := xlsxFile(t, map[string][][]interface{}{"Mitglieder": members, "Notizen": {{"nichts"}}}, []string{"Mitglieder", "Notizen"})
+	census, err := s.ParseAndValidate(bytes.NewReader(xlsx), 2024, ParseOptions{})
+	if err != nil {
+		t.Fatalf("xlsx first sheet: %v", err)
+	}
+	if census.MemberCount != 2 || census.Members[0].BirthYear != 1990 {
+		t.Errorf("xlsx: unexpected result %+v", census)
+	}
+
+	named := xlsxFile(t, map[string][][]interface{}{"Notizen": {{"nichts"}}, "Mitglieder": members}, []string{"Notizen", "Mitglieder"})
+	if census, err := s.ParseAndValidate(bytes.NewReader(named), 2024, ParseOptions{Sheet: "Mitglieder"}); err != nil || census.MemberCount != 2 {
+		t.Errorf("xlsx named sheet: %v %v", census, err)
+	}
+	if _, err := s.ParseAndValidate(bytes.NewReader(named), 2024, ParseOptions{Sheet: "Fehlt"}); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("xlsx missing sheet: got %v", err)
+	}
+
+	// Same validation rules as CSV
+	tooYoung := xlsxFile(t, map[string][][]interface{}{"Sheet1": {{"Erika", "Mustermann", 2023, "w"}}}, []string{"Sheet1"})
+	if upload, err := s.ParseAndValidate(bytes.NewReader(tooYoung), 2024, ParseOptions{}); err == nil || len(upload.Problems) != 1 || !strings.Contains(upload.Problems[0].Message, "too young") {
+		t.Errorf("xlsx validation: got %v", err)
+	}
+
+	ods := odsFile(t, odsContent)
+	census, err = s.ParseAndValidate(bytes.NewReader(ods), 2024, ParseOptions{Sheet: "Mitglieder"})
+	if err != nil {
+		t.Fatalf("ods: %v", err)
+	}
+	if census.MemberCount != 2 || census.Members[0].Firstname != "Jörg" || census.Members[0].BirthYear != 1990 || census.Members[1].BirthYear != 1985 {
+		t.Errorf("ods: unexpected result %+v", census)
+	}
+	if _, err := s.ParseAndValidate(bytes.NewReader(ods), 2024, ParseOptions{}); err == nil {
+		t.Error("ods first sheet has no members and should fail")
+	}
+
+	// Detection is by content, not extension
+	if census, err := s.ParseAndValidate(strings.NewReader("Erika,Mustermann,1990,w\n"), 2024, ParseOptions{}); err != nil || census.MemberCount != 1 {
+		t.Errorf("csv: %v %v", census, err)
+	}
+	var other bytes.Buffer
+	w := zip.NewWriter(&other)
+	w.Create("readme.txt")
+	w.Close()
+	if _, err := s.ParseAndValidate(&other, 2024, ParseOptions{}); err == nil || !strings.Contains(err.Error(), "unsupported file format") {
+		t.Errorf("other zip: got %v", err)
+	}
+}
+
+func TestReadODSFormattedText(t *testing.T) {
+	content := `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0" xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0">
+<office:body><office:spreadsheet>
+<table:table table:name="Mitglieder">
+<table:table-row><table:table-cell><text:p>Vorname</text:p></table:table-cell><table:table-cell><text:p>Nachname</text:p></table:table-cell></table:table-row>
+<table:table-row><table:table-cell><text:p>Anna<text:s/><text:span text:style-name="T1">Lena</text:span></text:p></table:table-cell><table:table-cell><text:p><text:span text:style-name="T2">von</text:span><text:s text:c="2"/>der <text:span text:style-name="T1">Heide</text:span><office:annotation><text:p>geprüft</text:p></office:annotation></text:p></table:table-cell></table:table-row>
+</table:table>
+</office:spreadsheet></office:body></office:document-content>`
+
+	rows, _, err := readODS(odsFile(t, content), "")
+	if err != nil {
+		t.Fatalf("readODS failed: %v", err)
+	}
+	if len(rows) != 2 || len(rows[1]) != 2 {
+		t.Fatalf("unexpected rows %q", rows)
+	}
+	if rows[1][0] != "Anna Lena" {
+		t.Errorf("first name = %q, want %q", rows[1][0], "Anna Lena")
+	}
+	if rows[1][1] != "von  der Heide" {
+		t.Errorf("last name = %q, want %q", rows[1][1], "von  der Heide")
+	}
+}
+
+func TestGenerateSampleXLSX(t *testing.T) {
+	s := &Service{}
+	sample, err := s.GenerateSampleXLSX("en")
+	if err != nil {
+		t.Fatalf("GenerateSampleXLSX failed: %v", err)
+	}
+	census, err := s.ParseAndValidate(bytes.NewReader(sample), 2024, ParseOptions{})
+	if err != nil {
+		t.Fatalf("sample does not validate: %v", err)
+	}
+	if census.MemberCount != 2 {
+		t.Errorf("expected 2 sample members, got %d", census.MemberCount)
+	}
+}
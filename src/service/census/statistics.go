@@ -0,0 +1,167 @@
+package census
+
+import (
+	"bytes"
+	"context"
+	"dpv/dpv/src/domain/dtos"
+	"dpv/dpv/src/domain/entities"
+	"dpv/dpv/src/repository/dpv"
+	"dpv/dpv/src/repository/t"
+	"encoding/csv"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// DefaultAgeBrackets are the lower bounds of the age brackets used by the Landessportbund
+// Bestandserhebung: 0-6, 7-14, 15-18, 19-26, 27-40, 41-60 and 61+.
+var DefaultAgeBrackets = []int{0, 7, 15, 19, 27, 41, 61}
+
+// AgeBrackets returns the configured age bracket bounds, or the default ones.
+func AgeBrackets() []int {
+	if dpv.ConfigInstance != nil && len(dpv.ConfigInstance.Settings.CensusAgeBrackets) > 0 {
+		return dpv.ConfigInstance.Settings.CensusAgeBrackets
+	}
+	return DefaultAgeBrackets
+}
+
+// ParseAgeBrackets parses ascending lower bounds like "0,7,15,19" starting at 0.
+// An empty value returns the configured brackets.
+func ParseAgeBrackets(s string) ([]int, error) {
+	if strings.TrimSpace(s) == "" {
+		return AgeBrackets(), nil
+	}
+	var bounds []int
+	for _, part := range strings.Split(s, ",") {
+		bound, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || bound < 0 {
+			return nil, t.Errorf("invalid age bracket '%s'", strings.TrimSpace(part))
+		}
+		if len(bounds) > 0 && bound <= bounds[len(bounds)-1] {
+			return nil, t.Errorf("age brackets must be ascending")
+		}
+		bounds = append(bounds, bound)
+	}
+	if bounds[0] != 0 {
+		return nil, t.Errorf("age brackets must start at 0")
+	}
+	return bounds, nil
+}
+
+// newStatistics returns empty statistics with a row per age bracket.
+func newStatistics(year int, bounds []int) *dtos.CensusStatistics {
+	stats := &dtos.CensusStatistics{Year: year, Genders: genderCounts()}
+	for i, lower := range bounds {
+		bracket := dtos.AgeBracketCount{Min: lower, Genders: genderCounts()}
+		if i+1 < len(bounds) {
+			upper := bounds[i+1] - 1
+			bracket.Max = &upper
+			bracket.Label = strconv.Itoa(lower) + "-" + strconv.Itoa(upper)
+		} else {
+			bracket.Label = strconv.Itoa(lower) + "+"
+		}
+		stats.Brackets = append(stats.Brackets, bracket)
+	}
+	return stats
+}
+
+func genderCounts() map[string]int {
+	counts := make(map[string]int, len(entities.Genders))
+	for _, gender := range entities.Genders {
+		counts[gender] = 0
+	}
+	return counts
+}
+
+// addCount counts members of a bracket. Genders not yet normalised count as unspecified.
+func addCount(stats *dtos.CensusStatistics, bracket int, gender string, n int) {
+	if !slices.Contains(entities.Genders, gender) {
+		gender = entities.GenderUnspecified
+	}
+	stats.Brackets[bracket].Genders[gender] += n
+	stats.Brackets[bracket].Total += n
+	stats.Genders[gender] += n
+	stats.Total += n
+}
+
+// bracketOf returns the index of the bracket of an age, or -1 if it is below the first bound.
+func bracketOf(bounds []int, age int) int {
+	bracket := -1
+	for i, lower := range bounds {
+		if age >= lower {
+			bracket = i
+		}
+	}
+	return bracket
+}
+
+// countMembers computes the statistics of a census. The age is the one reached in the census year.
+func countMembers(census *entities.Census, bounds []int) *dtos.CensusStatistics {
+	stats := newStatistics(census.Year, bounds)
+	stats.Clubs = 1
+	for _, member := range census.Members {
+		if bracket := bracketOf(bounds, census.Year-member.BirthYear); bracket >= 0 {
+			addCount(stats, bracket, member.Gender, 1)
+		}
+	}
+	return stats
+}
+
+// ClubStatistics counts the census members of a club by age bracket and gender (board members and admins).
+func (s *Service) ClubStatistics(ctx context.Context, clubKey string, year int, bounds []int, user *entities.User) (*dtos.CensusStatistics, error) {
+	census, err := s.Get(ctx, clubKey, year, user)
+	if err != nil {
+		return nil, err
+	}
+	stats := countMembers(census, bounds)
+	stats.ClubKey = clubKey
+	return stats, nil
+}
+
+// Statistics aggregates the accepted censuses of a year across all clubs, or across a Landesverband
+// and the clubs below it if parentKey is set (Admin only).
+func (s *Service) Statistics(ctx context.Context, year int, parentKey string, bounds []int) (*dtos.CensusStatistics, error) {
+	var clubKeys []string
+	if parentKey != "" {
+		keys, err := s.Db.GetClubSubtree(ctx, parentKey)
+		if err != nil {
+			return nil, err
+		}
+		clubKeys = keys
+	}
+	counts, err := s.Db.CountCensusMembers(ctx, year, clubKeys, bounds)
+	if err != nil {
+		return nil, err
+	}
+	stats := newStatistics(year, bounds)
+	stats.ParentKey = parentKey
+	stats.Clubs = counts.Clubs
+	for _, c := range counts.Counts {
+		if c.Bracket >= 0 && c.Bracket < len(bounds) {
+			addCount(stats, c.Bracket, c.Gender, c.Count)
+		}
+	}
+	return stats, nil
+}
+
+// StatisticsCSV returns statistics as CSV with a row per age bracket and a total row.
+func StatisticsCSV(stats *dtos.CensusStatistics) []byte {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+
+	row := func(label string, genders map[string]int, total int) []string {
+		r := []string{label}
+		for _, gender := range entities.Genders {
+			r = append(r, strconv.Itoa(genders[gender]))
+		}
+		return append(r, strconv.Itoa(total))
+	}
+	writer.Write(append(append([]string{"age_group"}, entities.Genders...), "total"))
+	for _, b := range stats.Brackets {
+		writer.Write(row(b.Label, b.Genders, b.Total))
+	}
+	writer.Write(row("total", stats.Genders, stats.Total))
+
+	writer.Flush()
+	return buffer.Bytes()
+}
@@ -0,0 +1,55 @@
+package census
+
+import (
+	"dpv/dpv/src/domain/entities"
+	"strings"
+	"testing"
+)
+
+func TestParseAgeBrackets(t *testing.T) {
+	if bounds, err := ParseAgeBrackets(""); err != nil || len(bounds) != len(DefaultAgeBrackets) {
+		t.Errorf("ParseAgeBrackets(\"\") = %v, %v", bounds, err)
+	}
+	if bounds, err := ParseAgeBrackets("0, 18, 65"); err != nil || len(bounds) != 3 || bounds[2] != 65 {
+		t.Errorf("ParseAgeBrackets() = %v, %v", bounds, err)
+	}
+	for _, invalid := range []string{"0,x", "0,18,10", "0,18,18", "6,18", "-1,5"} {
+		if _, err := ParseAgeBrackets(invalid); err == nil {
+			t.Errorf("ParseAgeBrackets(%q) expected error", invalid)
+		}
+	}
+}
+
+func TestCountMembers(t *testing.T) {
+	census := &entities.Census{Year: 2024, Members: []entities.MemberRow{
+		{Gender: entities.GenderFemale, BirthYear: 2018},  // 6
+		{Gender: entities.GenderMale, BirthYear: 2017},    // 7
+		{Gender: entities.GenderMale, BirthYear: 2006},    // 18
+		{Gender: entities.GenderDiverse, BirthYear: 2005}, // 19
+		{Gender: "w", BirthYear: 1963},                    // 61, not normalised
+		{Gender: entities.GenderFemale, BirthYear: 1930},  // 94
+	}}
+	stats := countMembers(census, DefaultAgeBrackets)
+
+	if stats.Total != 6 || stats.Clubs != 1 || stats.Genders[entities.GenderFemale] != 2 || stats.Genders[entities.GenderUnspecified] != 1 {
+		t.Errorf("countMembers() totals = %d, %v", stats.Total, stats.Genders)
+	}
+	want := map[string]int{"0-6": 1, "7-14": 1, "15-18": 1, "19-26": 1, "27-40": 0, "41-60": 0, "61+": 2}
+	if len(stats.Brackets) != len(want) {
+		t.Fatalf("countMembers() brackets = %+v", stats.Brackets)
+	}
+	for _, b := range stats.Brackets {
+		if b.Total != want[b.Label] {
+			t.Errorf("bracket %s = %d, want %d", b.Label, b.Total, want[b.Label])
+		}
+	}
+	if last := stats.Brackets[len(stats.Brackets)-1]; last.Max != nil || last.Min != 61 {
+		t.Errorf("last bracket = %+v, want open-ended", last)
+	}
+
+	csv := string(StatisticsCSV(stats))
+	lines := strings.Split(strings.TrimSpace(csv), "\n")
+	if lines[0] != "age_group,female,male,diverse,unspecified,total" || lines[1] != "0-6,1,0,0,0,1" || lines[len(lines)-1] != "total,2,2,1,1,6" {
+		t.Errorf("StatisticsCSV() = %q", csv)
+	}
+}
@@ -0,0 +1,93 @@
+package census
+
+import (
+	"context"
+	"dpv/dpv/src/domain/dtos"
+	"dpv/dpv/src/domain/entities"
+	"dpv/dpv/src/repository/t"
+	"time"
+)
+
+// Submit submits a draft or returned census for review (board members and admins). Submitted censuses
+// are locked against further uploads by the board until an admin returns them. Returned censuses can be
+// submitted again after the deadline, see CheckOpen.
+func (s *Service) Submit(ctx context.Context, clubKey string, year int, user *entities.User) (*entities.Census, error) {
+	if err := s.Authorize(ctx, clubKey, user); err != nil {
+		return nil, err
+	}
+	if err := s.CheckOpen(ctx, clubKey, year, user); err != nil {
+		return nil, err
+	}
+	census, err := s.Db.GetCensus(ctx, clubKey, year)
+	if err != nil {
+		return nil, err
+	}
+	state := census.State()
+	if state != entities.CensusDraft && state != entities.CensusReturned {
+		return nil, t.Conflict(t.Errorf("only draft or returned censuses can be submitted"))
+	}
+	census.Status = entities.CensusSubmitted
+	census.SubmitterKey = user.Key
+	census.Submitted = time.Now()
+	if err := s.transition(ctx, census, state); err != nil {
+		return nil, err
+	}
+	return census, nil
+}
+
+// transition stores a census whose workflow state was changed from the given one, failing if another
+// request changed the state meanwhile.
+func (s *Service) transition(ctx context.Context, census *entities.Census, from string) error {
+	updated, err := s.Db.UpdateCensusIfStatus(ctx, census, []string{from})
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return errCensusChanged
+	}
+	return nil
+}
+
+// Review accepts a submitted census or returns it to the board with a comment (Admin only). Only
+// accepted censuses are counted in club summaries and statistics; accepted censuses may be returned
+// again to be corrected.
+func (s *Service) Review(ctx context.Context, clubKey string, year int, accepted bool, comment string, reviewer *entities.User) (*entities.Census, error) {
+	if !accepted && comment == "" {
+		return nil, t.Errorf("a comment is required when returning a census")
+	}
+	census, err := s.Db.GetCensus(ctx, clubKey, year)
+	if err != nil {
+		return nil, err
+	}
+	state := census.State()
+	if accepted && state != entities.CensusSubmitted {
+		return nil, t.Conflict(t.Errorf("only submitted censuses can be accepted"))
+	}
+	if !accepted && state != entities.CensusSubmitted && state != entities.CensusAccepted {
+		return nil, t.Conflict(t.Errorf("only submitted or accepted censuses can be returned"))
+	}
+
+	if accepted {
+		census.Status = entities.CensusAccepted
+	} else {
+		census.Status = entities.CensusReturned
+	}
+	census.Comment = comment
+	census.ReviewerKey = reviewer.Key
+	census.Reviewed = time.Now()
+	if err := s.transition(ctx, census, state); err != nil {
+		return nil, err
+	}
+	return census, nil
+}
+
+// ListByStatus lists the clubs whose census of a year is in the given workflow state, e.g. the
+// submitted censuses waiting for review (Admin only).
+func (s *Service) ListByStatus(ctx context.Context, year int, status string) ([]dtos.CensusStatus, error) {
+	switch status {
+	case entities.CensusDraft, entities.CensusSubmitted, entities.CensusAccepted, entities.CensusReturned:
+	default:
+		return nil, t.Errorf("invalid census status '%s'", status)
+	}
+	return s.Db.GetCensusStatuses(ctx, year, status)
+}
@@ -0,0 +1,58 @@
+package census
+
+import (
+	"context"
+	"dpv/dpv/src/domain/entities"
+	"testing"
+	"time"
+)
+
+func TestCensusIsLocked(t *testing.T) {
+	tests := map[string]bool{
+		entities.CensusDraft:     false,
+		entities.CensusSubmitted: true,
+		entities.CensusAccepted:  true,
+		entities.CensusReturned:  false,
+		"":                       true, // stored before the workflow, accepted
+	}
+	for status, want := range tests {
+		census := &entities.Census{Status: status}
+		if got := census.IsLocked(); got != want {
+			t.Errorf("IsLocked() for %q = %v, want %v", status, got, want)
+		}
+	}
+}
+
+func TestReviewRequiresCommentToReturn(t *testing.T) {
+	s := &Service{}
+	if _, err := s.Review(context.Background(), "club", 2025, false, "", &entities.User{}); err == nil {
+		t.Error("Review() without comment should fail when returning a census")
+	}
+	if _, err := s.ListByStatus(context.Background(), 2025, "overdue"); err == nil {
+		t.Error("ListByStatus() should reject states other than the workflow states")
+	}
+}
+
+func TestReuploaded(t *testing.T) {
+	reviewed := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	for status, want := range map[string]string{
+		entities.CensusDraft:     entities.CensusDraft,
+		entities.CensusReturned:  entities.CensusReturned,
+		entities.CensusSubmitted: entities.CensusSubmitted,
+		entities.CensusAccepted:  entities.CensusSubmitted,
+		"":                       entities.CensusSubmitted, // stored before the workflow, accepted
+	} {
+		existing := &entities.Census{Entity: entities.Key("42"), Status: status, Comment: "Jahrgang fehlt", SubmitterKey: "board", ReviewerKey: "admin", Reviewed: reviewed}
+		census := &entities.Census{Year: 2025}
+		reuploaded(existing, census)
+		if census.GetKey() != "42" || census.Status != want || census.SubmitterKey != "board" {
+			t.Errorf("reuploaded() over %q = %+v, want status %q", status, census, want)
+		}
+		if existing.IsLocked() && (census.ReviewerKey != "" || !census.Reviewed.IsZero() || census.Comment != "") {
+			t.Errorf("reuploaded() over %q kept the review: %+v", status, census)
+		}
+		if !existing.IsLocked() && (census.ReviewerKey != "admin" || census.Comment != "Jahrgang fehlt") {
+			t.Errorf("reuploaded() over %q lost the review: %+v", status, census)
+		}
+	}
+}
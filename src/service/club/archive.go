@@ -0,0 +1,60 @@
+package club
+
+import (
+	"context"
+	"dpv/dpv/src/domain/entities"
+	"dpv/dpv/src/repository/t"
+	"time"
+)
+
+// RetentionYears is how long census and document history of a dissolved club is kept (§ 147 AO).
+const RetentionYears = 10
+
+// RetentionEnd returns when an archived club may be purged.
+func RetentionEnd(club *entities.Club) time.Time {
+	if club.Archived == nil {
+		return time.Time{}
+	}
+	return club.Archived.AddDate(RetentionYears, 0, 0)
+}
+
+// RestoreClub brings an archived club back (Admin only).
+func (s *Service) RestoreClub(ctx context.Context, key string) error {
+	club, err := s.DB.GetClubByKey(ctx, key)
+	if err != nil {
+		return t.Errorf("failed to load club for restore: %w", err)
+	}
+	if club.Archived == nil {
+		return t.Errorf("club is not archived")
+	}
+
+	club.Archived = nil
+	club.ArchivedBy = ""
+	if err := s.DB.UpdateClub(ctx, club); err != nil {
+		return t.Errorf("failed to restore club: %w", err)
+	}
+	return nil
+}
+
+// PurgeClub permanently deletes an archived club with its census data and stored documents (Admin only).
+// Unless force is set, the club must have been archived for the whole retention period.
+func (s *Service) PurgeClub(ctx context.Context, key string, force bool) error {
+	club, err := s.DB.GetClubByKey(ctx, key)
+	if err != nil {
+		return t.Errorf("failed to load club for purge: %w", err)
+	}
+	if club.Archived == nil {
+		return t.Errorf("only archived clubs can be purged")
+	}
+	if end := RetentionEnd(club); !force && time.Now().Before(end) {
+		return t.Errorf("retention period ends on %s", end.Format(time.DateOnly))
+	}
+
+	if err := s.DB.PurgeClub(ctx, club); err != nil {
+		return t.Errorf("failed to purge club: %w", err)
+	}
+	if err := s.Storage.DeleteDocuments("clubs", key); err != nil {
+		return t.Errorf("failed to delete club documents: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package club
+
+import (
+	"dpv/dpv/src/domain/entities"
+	"testing"
+	"time"
+)
+
+func TestRetentionEnd(t *testing.T) {
+	if !RetentionEnd(&entities.Club{}).IsZero() {
+		t.Error("clubs that are not archived have no retention end")
+	}
+
+	archived := time.Date(2024, 3, 15, 10, 0, 0, 0, time.UTC)
+	club := &entities.Club{Archived: &archived}
+	want := time.Date(2034, 3, 15, 10, 0, 0, 0, time.UTC)
+	if got := RetentionEnd(club); !got.Equal(want) {
+		t.Errorf("RetentionEnd() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,165 @@
+package club
+
+import (
+	"context"
+	"dpv/dpv/src/api"
+	"dpv/dpv/src/domain/entities"
+	"dpv/dpv/src/repository/t"
+	"slices"
+	"strings"
+	"time"
+)
+
+// UpdateBoardFunction changes the function and term of a board member. Fields missing from the updates
+// keep their stored value, an empty string clears them. Making someone treasurer needs an admin or the
+// current treasurer, and nobody can make themselves treasurer.
+func (s *Service) UpdateBoardFunction(ctx context.Context, clubKey, memberKey string, updates map[string]interface{}, actor *entities.User) error {
+	authorized, err := s.IsAuthorized(ctx, actor, clubKey)
+	if err != nil {
+		return t.Errorf("authorization check failed while updating board function: %w", err)
+	}
+	if !authorized {
+		return t.Errorf("unauthorized: you cannot manage owners for this club")
+	}
+
+	club, err := s.DB.GetClubByKey(ctx, clubKey)
+	if err != nil {
+		return t.Errorf("failed to load club for update: %w", err)
+	}
+	i := slices.IndexFunc(club.Vorstand, func(v entities.VorstandUser) bool { return v.Key == memberKey })
+	if i < 0 {
+		return t.Errorf("board member not found")
+	}
+	stored := club.Vorstand[i]
+	member := stored
+	if err := applyBoardUpdates(&member, updates); err != nil {
+		return err
+	}
+	if err := validateBoardFunction(&member); err != nil {
+		return err
+	}
+	if grantsTreasurer(stored, member) {
+		if err := s.checkTreasurerAssignment(ctx, clubKey, member.Key, actor); err != nil {
+			return err
+		}
+	}
+	return s.DB.UpdateVorstand(ctx, clubKey, member)
+}
+
+func applyBoardUpdates(member *entities.VorstandUser, updates map[string]interface{}) error {
+	var err error
+	if f, ok := updates["function"].(string); ok {
+		member.Function = f
+	}
+	if title, ok := updates["title"].(string); ok {
+		member.Title = title
+	}
+	if start, ok := updates["term_start"].(string); ok {
+		if member.TermStart, err = parseTermDate(start); err != nil {
+			return err
+		}
+	}
+	if end, ok := updates["term_end"].(string); ok {
+		if member.TermEnd, err = parseTermDate(end); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseTermDate parses an optional YYYY-MM-DD date
+func parseTermDate(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	d, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return nil, t.Errorf("invalid date %s, expected YYYY-MM-DD", s)
+	}
+	return &d, nil
+}
+
+// grantsTreasurer reports whether an update makes a member treasurer or changes a treasurer's term.
+// Either gives access to the club's bank details, so it needs the same permission.
+func grantsTreasurer(before, after entities.VorstandUser) bool {
+	if after.Function != entities.FunctionKassenwart {
+		return false
+	}
+	return before.Function != entities.FunctionKassenwart ||
+		!sameDate(before.TermStart, after.TermStart) || !sameDate(before.TermEnd, after.TermEnd)
+}
+
+func sameDate(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// checkTreasurerAssignment allows admins and the current treasurer to assign the treasurer of a club.
+func (s *Service) checkTreasurerAssignment(ctx context.Context, clubKey, memberKey string, actor *entities.User) error {
+	if api.IsAdmin(*actor) {
+		return nil
+	}
+	if memberKey == actor.Key {
+		return t.Errorf("unauthorized: you cannot make yourself treasurer")
+	}
+	treasurer, err := s.HasBoardFunction(ctx, actor, clubKey, entities.FunctionKassenwart)
+	if err != nil {
+		return err
+	}
+	if !treasurer {
+		return t.Errorf("unauthorized: only the treasurer or an admin can assign the treasurer")
+	}
+	return nil
+}
+
+func validateBoardFunction(member *entities.VorstandUser) error {
+	member.Title = strings.TrimSpace(member.Title)
+	if member.Function != "" && !slices.Contains(entities.BoardFunctions, member.Function) {
+		return t.Errorf("invalid board function %s", member.Function)
+	}
+	if member.Function == entities.FunctionCustom && member.Title == "" {
+		return t.Errorf("custom board function requires a title")
+	}
+	if member.Function != entities.FunctionCustom {
+		member.Title = ""
+	}
+	if member.TermStart != nil && member.TermEnd != nil && member.TermEnd.Before(*member.TermStart) {
+		return t.Errorf("term end must not be before term start")
+	}
+	return nil
+}
+
+// GetBoardFunctionHolders returns the board members currently holding a function, e.g. the treasurer of a club.
+func (s *Service) GetBoardFunctionHolders(ctx context.Context, clubKey, function string) ([]entities.VorstandUser, error) {
+	members, err := s.DB.GetVorstandByFunction(ctx, clubKey, function)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	var result []entities.VorstandUser
+	for _, m := range members {
+		if m.InOffice(now) {
+			result = append(result, m)
+		}
+	}
+	return result, nil
+}
+
+// HasBoardFunction reports whether the user currently holds the function in the club. Admins always do.
+func (s *Service) HasBoardFunction(ctx context.Context, user *entities.User, clubKey, function string) (bool, error) {
+	if api.IsAdmin(*user) {
+		return true, nil
+	}
+	holders, err := s.GetBoardFunctionHolders(ctx, clubKey, function)
+	if err != nil {
+		return false, t.Errorf("failed to look up board function: %w", err)
+	}
+	for _, h := range holders {
+		if h.Key == user.Key {
+			return true, nil
+		}
+	}
+	return false, nil
+}
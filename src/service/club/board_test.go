@@ -0,0 +1,115 @@
+package club
+
+import (
+	"context"
+	"dpv/dpv/src/domain/entities"
+	"testing"
+	"time"
+)
+
+func TestValidateBoardFunction(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		member  entities.VorstandUser
+		wantErr bool
+	}{
+		{"no function", entities.VorstandUser{}, false},
+		{"treasurer", entities.VorstandUser{Function: entities.FunctionKassenwart}, false},
+		{"unknown function", entities.VorstandUser{Function: "praesident"}, true},
+		{"custom with title", entities.VorstandUser{Function: entities.FunctionCustom, Title: "Schriftführer"}, false},
+		{"custom without title", entities.VorstandUser{Function: entities.FunctionCustom, Title: " "}, true},
+		{"term end before start", entities.VorstandUser{Function: entities.FunctionVorsitz, TermStart: &start, TermEnd: &end}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateBoardFunction(&tt.member)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateBoardFunction() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	member := entities.VorstandUser{Function: entities.FunctionJugendwart, Title: "ignored"}
+	if err := validateBoardFunction(&member); err != nil || member.Title != "" {
+		t.Errorf("title should be cleared for predefined functions, got %q (err %v)", member.Title, err)
+	}
+}
+
+func TestInOffice(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+	member := entities.VorstandUser{TermStart: &start, TermEnd: &end}
+
+	if member.InOffice(start.Add(-time.Hour)) {
+		t.Error("should not be in office before the term starts")
+	}
+	if !member.InOffice(end.Add(12 * time.Hour)) {
+		t.Error("should be in office on the last day of the term")
+	}
+	if member.InOffice(end.AddDate(0, 0, 1)) {
+		t.Error("should not be in office after the term ends")
+	}
+	if !(&entities.VorstandUser{}).InOffice(time.Now()) {
+		t.Error("members without a term should always be in office")
+	}
+}
+
+func TestApplyBoardUpdates(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	member := entities.VorstandUser{Function: entities.FunctionCustom, Title: "Schriftführer", TermStart: &start}
+
+	if err := applyBoardUpdates(&member, map[string]interface{}{"term_end": "2025-12-31"}); err != nil {
+		t.Fatalf("applyBoardUpdates failed: %v", err)
+	}
+	if member.Function != entities.FunctionCustom || member.Title != "Schriftführer" || member.TermStart == nil {
+		t.Errorf("omitted fields should be kept, got %+v", member)
+	}
+	if member.TermEnd == nil || !member.TermEnd.Equal(time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("term end = %v, want 2025-12-31", member.TermEnd)
+	}
+
+	if err := applyBoardUpdates(&member, map[string]interface{}{"term_start": ""}); err != nil || member.TermStart != nil {
+		t.Errorf("an empty term start should clear it, got %v (err %v)", member.TermStart, err)
+	}
+	if err := applyBoardUpdates(&member, map[string]interface{}{"term_end": "31.12.2025"}); err == nil {
+		t.Error("expected an error for an invalid date")
+	}
+}
+
+func TestGrantsTreasurer(t *testing.T) {
+	end := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+	later := end.AddDate(1, 0, 0)
+	treasurer := entities.VorstandUser{Function: entities.FunctionKassenwart, TermEnd: &end}
+
+	tests := []struct {
+		name          string
+		before, after entities.VorstandUser
+		want          bool
+	}{
+		{"becomes treasurer", entities.VorstandUser{Function: entities.FunctionVorsitz}, treasurer, true},
+		{"term extended", treasurer, entities.VorstandUser{Function: entities.FunctionKassenwart, TermEnd: &later}, true},
+		{"unchanged treasurer", treasurer, treasurer, false},
+		{"treasurer steps down", treasurer, entities.VorstandUser{Function: entities.FunctionVorsitz}, false},
+		{"other function", entities.VorstandUser{}, entities.VorstandUser{Function: entities.FunctionJugendwart}, false},
+	}
+	for _, tt := range tests {
+		if got := grantsTreasurer(tt.before, tt.after); got != tt.want {
+			t.Errorf("%s: grantsTreasurer() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCheckTreasurerAssignment(t *testing.T) {
+	s := &Service{}
+	admin := &entities.User{Entity: entities.Entity{Key: "admin"}, Roles: []string{"admin"}}
+	if err := s.checkTreasurerAssignment(context.Background(), "club1", "admin", admin); err != nil {
+		t.Errorf("admins may assign the treasurer: %v", err)
+	}
+	member := &entities.User{Entity: entities.Entity{Key: "member"}}
+	if err := s.checkTreasurerAssignment(context.Background(), "club1", "member", member); err == nil {
+		t.Error("board members must not make themselves treasurer")
+	}
+}
@@ -0,0 +1,94 @@
+package club
+
+import (
+	"context"
+	"dpv/dpv/src/domain/entities"
+	"dpv/dpv/src/repository/graph"
+	"dpv/dpv/src/repository/t"
+	"errors"
+)
+
+// MaxBulkClubs limits the number of clubs in a single bulk request.
+const MaxBulkClubs = 500
+
+// BulkRequest applies one action to a list of clubs.
+type BulkRequest struct {
+	Action  string                 `json:"action"` // approve, deny, cancel, update
+	Keys    []string               `json:"keys"`
+	Updates map[string]interface{} `json:"updates,omitempty"` // Fields for the update action, as in PATCH /dpv/clubs/:key
+	Atomic  bool                   `json:"atomic"`            // All or nothing
+}
+
+// BulkResult is the outcome of a bulk action for one club.
+type BulkResult struct {
+	Key   string `json:"_key"`
+	OK    bool   `json:"ok"`
+	Err   error  `json:"-"`
+	Error string `json:"error,omitempty"` // Translated by the caller
+}
+
+// Bulk applies an action to each club with the same rules as the single-club operations (Admin only).
+// In atomic mode all changes run in one transaction that is rolled back if any club fails;
+// committed reports whether the changes were stored.
+func (s *Service) Bulk(ctx context.Context, req BulkRequest, user *entities.User) (results []BulkResult, committed bool, err error) {
+	if len(req.Keys) == 0 {
+		return nil, false, t.Errorf("no clubs given")
+	}
+	if len(req.Keys) > MaxBulkClubs {
+		return nil, false, t.Errorf("at most %d clubs can be changed at once", MaxBulkClubs)
+	}
+	action, err := bulkActionFor(req)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !req.Atomic {
+		return s.applyBulk(ctx, req.Keys, action, user), true, nil
+	}
+
+	errFailed := t.Errorf("bulk operation rolled back")
+	err = s.DB.WithTransaction(ctx, []string{"clubs"}, func(tx *graph.Db) error {
+		txService := &Service{DB: tx, Storage: s.Storage, Verifier: s.Verifier}
+		results = txService.applyBulk(ctx, req.Keys, action, user)
+		for _, r := range results {
+			if !r.OK {
+				return errFailed
+			}
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errFailed) {
+		return nil, false, t.Errorf("bulk transaction failed: %w", err)
+	}
+	return results, err == nil, nil
+}
+
+type bulkAction func(s *Service, ctx context.Context, key string, user *entities.User) error
+
+func bulkActionFor(req BulkRequest) (bulkAction, error) {
+	switch req.Action {
+	case "approve":
+		return func(s *Service, ctx context.Context, key string, _ *entities.User) error { return s.Approve(ctx, key) }, nil
+	case "deny":
+		return func(s *Service, ctx context.Context, key string, _ *entities.User) error { return s.Deny(ctx, key) }, nil
+	case "cancel":
+		return (*Service).Cancel, nil
+	case "update":
+		if len(req.Updates) == 0 {
+			return nil, t.Errorf("no updates given")
+		}
+		return func(s *Service, ctx context.Context, key string, user *entities.User) error {
+			return s.UpdateClub(ctx, key, req.Updates, user)
+		}, nil
+	}
+	return nil, t.Errorf("invalid bulk action %s", req.Action)
+}
+
+func (s *Service) applyBulk(ctx context.Context, keys []string, action bulkAction, user *entities.User) []BulkResult {
+	results := make([]BulkResult, 0, len(keys))
+	for _, key := range keys {
+		err := action(s, ctx, key, user)
+		results = append(results, BulkResult{Key: key, OK: err == nil, Err: err})
+	}
+	return results
+}
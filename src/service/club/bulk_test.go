@@ -0,0 +1,38 @@
+package club
+
+import (
+	"context"
+	"dpv/dpv/src/domain/entities"
+	"testing"
+)
+
+func TestBulkValidation(t *testing.T) {
+	s := &Service{}
+	admin := &entities.User{Entity: entities.Entity{Key: "admin"}, Roles: []string{"admin"}}
+
+	tests := []struct {
+		name string
+		req  BulkRequest
+	}{
+		{"no keys", BulkRequest{Action: "approve"}},
+		{"too many keys", BulkRequest{Action: "approve", Keys: make([]string, MaxBulkClubs+1)}},
+		{"unknown action", BulkRequest{Action: "delete", Keys: []string{"a"}}},
+		{"update without fields", BulkRequest{Action: "update", Keys: []string{"a"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := s.Bulk(context.Background(), tt.req, admin); err == nil {
+				t.Error("expected the request to be rejected")
+			}
+		})
+	}
+
+	for _, action := range []string{"approve", "deny", "cancel"} {
+		if _, err := bulkActionFor(BulkRequest{Action: action}); err != nil {
+			t.Errorf("action %s should be supported: %v", action, err)
+		}
+	}
+	if _, err := bulkActionFor(BulkRequest{Action: "update", Updates: map[string]interface{}{"contribution": 50.0}}); err != nil {
+		t.Errorf("update action should be supported: %v", err)
+	}
+}
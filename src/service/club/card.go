@@ -0,0 +1,23 @@
+package club
+
+import (
+	"context"
+	"dpv/dpv/src/domain/entities"
+	"dpv/dpv/src/repository/dpv"
+	"dpv/dpv/src/repository/t"
+	"dpv/dpv/src/service/membership"
+	"time"
+)
+
+// Card issues the membership card of an active club to its board or an admin.
+func (s *Service) Card(ctx context.Context, key string, user *entities.User) (*membership.Card, error) {
+	club, err := s.GetClub(ctx, key, user)
+	if err != nil {
+		return nil, t.Errorf("failed to load club for membership card: %w", err)
+	}
+	if club.Archived != nil {
+		return nil, t.Errorf("club is archived")
+	}
+	return membership.NewCard(membership.CardClub, club.Key, club.Name, club,
+		dpv.ConfigInstance.Settings.BaseURL, dpv.ConfigInstance.Auth.DpvSecretKey, time.Now())
+}
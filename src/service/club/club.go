@@ -4,18 +4,23 @@ import (
 	"context"
 	"dpv/dpv/src/api"
 	"dpv/dpv/src/domain/entities"
+	"dpv/dpv/src/repository/geo"
 	"dpv/dpv/src/repository/graph"
 	"dpv/dpv/src/repository/storage"
 	"dpv/dpv/src/repository/t"
+	"dpv/dpv/src/service/membership"
+	"strings"
+	"time"
 )
 
 type Service struct {
-	DB      *graph.Db
-	Storage *storage.Storage
+	DB       *graph.Db
+	Storage  *storage.Storage
+	Verifier *WebsiteVerifier
 }
 
 func NewService(db *graph.Db, st *storage.Storage) *Service {
-	return &Service{DB: db, Storage: st}
+	return &Service{DB: db, Storage: st, Verifier: NewWebsiteVerifier()}
 }
 
 // CreateClub performs business validation and creates a new club.
@@ -26,17 +31,23 @@ func (s *Service) CreateClub(ctx context.Context, club *entities.Club, userKey s
 	if club.LegalForm == "" {
 		return t.Errorf("legal_form must not be empty")
 	}
+	if club.Website != "" {
+		if _, err := NormalizeWebsite(club.Website); err != nil {
+			return err
+		}
+	}
 
 	// Default status
 	if club.Membership.Status == "" {
 		club.Membership.Status = "inactive"
 	}
 	club.OwnerKey = userKey
+	locateClub(club)
 
 	return s.DB.CreateClub(ctx, club, userKey)
 }
 
-// IsAuthorized checks if a user is an admin or a board member of the club.
+// IsAuthorized checks if a user is an admin or a board member of the club whose term has not ended.
 func (s *Service) IsAuthorized(ctx context.Context, user *entities.User, clubKey string) (bool, error) {
 	if api.IsAdmin(*user) {
 		return true, nil
@@ -87,7 +98,17 @@ func (s *Service) UpdateClub(ctx context.Context, key string, updates map[string
 	}
 
 	// Apply updates
-	// Note: Status, Contribution, Members, and Votes are restricted.
+	// Note: Status, Members, and Votes are restricted, Contribution is admin only.
+	if c, ok := updates["contribution"]; ok {
+		contribution, ok := c.(float64)
+		if !ok || contribution < 0 {
+			return t.Errorf("contribution must be a non-negative number")
+		}
+		if !api.IsAdmin(*user) {
+			return t.Errorf("unauthorized: only admins can change the contribution")
+		}
+		club.Membership.Contribution = contribution
+	}
 	if name, ok := updates["name"].(string); ok && name != "" {
 		club.Name = name
 	}
@@ -101,14 +122,48 @@ func (s *Service) UpdateClub(ctx context.Context, key string, updates map[string
 		club.ContactPerson = cp
 	}
 	if iban, ok := updates["iban"].(string); ok {
-		club.Membership.IBAN = iban
+		if err := membership.SetIBAN(club, iban); err != nil {
+			return err
+		}
 	}
 	if sepam, ok := updates["sepa_mandate_number"].(string); ok {
-		club.Membership.SEPAMandateNumber = sepam
+		if err := membership.SetSEPAMandateNumber(club, sepam); err != nil {
+			return err
+		}
 	}
 	if addr, ok := updates["address"].(string); ok {
 		club.Membership.Address = addr
 	}
+	if city, ok := updates["city"].(string); ok {
+		club.City = city
+	}
+	if state, ok := updates["federal_state"].(string); ok {
+		state = strings.ToUpper(strings.TrimPrefix(strings.TrimSpace(state), "DE-"))
+		if _, known := geo.FederalStates[state]; state != "" && !known {
+			return t.Errorf("unknown federal state '%s'", state)
+		}
+		club.FederalState = state
+	}
+	if pe, ok := updates["public_email"].(string); ok {
+		club.PublicEmail = pe
+	}
+	if pl, ok := updates["public_listing"].(bool); ok {
+		club.PublicListing = pl
+	}
+	if website, ok := updates["website"].(string); ok && website != club.Website {
+		if website != "" {
+			if _, err := NormalizeWebsite(website); err != nil {
+				return err
+			}
+		}
+		club.Website = website
+		club.WebsiteOK = false
+		club.WebsiteVerification = time.Time{}
+	}
+
+	if err := applyLocationUpdates(club, updates); err != nil {
+		return err
+	}
 
 	if err := s.DB.UpdateClub(ctx, club); err != nil {
 		return t.Errorf("failed to update club: %w", err)
@@ -116,7 +171,8 @@ func (s *Service) UpdateClub(ctx context.Context, key string, updates map[string
 	return nil
 }
 
-// DeleteClub deletes a club if the user is authorized.
+// DeleteClub dissolves a club if the user is authorized. The club is archived rather than
+// deleted, so its census and document history is kept until an admin purges it.
 func (s *Service) DeleteClub(ctx context.Context, key string, user *entities.User) error {
 	authorized, err := s.IsAuthorized(ctx, user, key)
 	if err != nil {
@@ -130,33 +186,40 @@ func (s *Service) DeleteClub(ctx context.Context, key string, user *entities.Use
 	if err != nil {
 		return t.Errorf("failed to load club for deletion: %w", err)
 	}
+	if club.Archived != nil {
+		return t.Errorf("club is already archived")
+	}
 
-	if err := s.DB.DeleteClub(ctx, club); err != nil {
-		return t.Errorf("failed to delete club: %w", err)
+	now := time.Now()
+	club.Archived = &now
+	club.ArchivedBy = user.Key
+	if err := s.DB.UpdateClub(ctx, club); err != nil {
+		return t.Errorf("failed to archive club: %w", err)
 	}
 	return nil
 }
 
-// AddOwner adds a user as a club owner by email.
-func (s *Service) AddOwner(ctx context.Context, clubKey, email string, actor *entities.User) error {
+// AddOwner adds a user as a club owner by email. If nobody is registered with that email,
+// an invitation is sent instead and returned.
+func (s *Service) AddOwner(ctx context.Context, clubKey, email string, actor *entities.User) (*entities.Invitation, error) {
 	authorized, err := s.IsAuthorized(ctx, actor, clubKey)
 	if err != nil {
-		return t.Errorf("authorization check failed while adding owner: %w", err)
+		return nil, t.Errorf("authorization check failed while adding owner: %w", err)
 	}
 	if !authorized {
-		return t.Errorf("unauthorized: you cannot manage owners for this club")
+		return nil, t.Errorf("unauthorized: you cannot manage owners for this club")
 	}
 
 	users, err := s.DB.GetUsersByEmail(ctx, email)
 	if err != nil {
-		return t.Errorf("failed to search user: %w", err)
+		return nil, t.Errorf("failed to search user: %w", err)
 	}
 	if len(users) == 0 {
-		return t.Errorf("user with email %s not found", email)
+		return s.invite(ctx, clubKey, email, entities.InvitationBoard, actor)
 	}
 	targetUser := users[0]
 
-	return s.DB.AddVorstand(ctx, clubKey, targetUser.Key)
+	return nil, s.DB.AddVorstand(ctx, clubKey, targetUser.Key)
 }
 
 // RemoveOwner removes a user from club owners.
@@ -0,0 +1,89 @@
+package club
+
+import (
+	"context"
+	"dpv/dpv/src/domain/dtos"
+	"dpv/dpv/src/domain/entities"
+	"dpv/dpv/src/repository/graph"
+	"dpv/dpv/src/repository/t"
+	"time"
+)
+
+// licenceRank orders licence states from best to worst for the summary status of a coach.
+var licenceRank = map[string]int{
+	entities.QualificationValid:      0,
+	entities.QualificationExpiring:   1,
+	entities.QualificationExpired:    2,
+	entities.QualificationUnverified: 3,
+	entities.QualificationRejected:   4,
+}
+
+// AddCoach invites a person by email to be listed as coach of the club. The club sees the coach's
+// licences, so the coach has to accept with the verified invited address. The invitation is sent
+// whether or not the address is registered, so the response does not reveal registered addresses.
+func (s *Service) AddCoach(ctx context.Context, clubKey, email string, actor *entities.User) (*entities.Invitation, error) {
+	if err := s.requireAuthorized(ctx, clubKey, actor); err != nil {
+		return nil, err
+	}
+	return s.invite(ctx, clubKey, email, entities.InvitationCoach, actor)
+}
+
+// RemoveCoach removes a user from the coaches of the club.
+func (s *Service) RemoveCoach(ctx context.Context, clubKey, userKey string, actor *entities.User) error {
+	if err := s.requireAuthorized(ctx, clubKey, actor); err != nil {
+		return err
+	}
+	return s.DB.RemoveCoach(ctx, clubKey, userKey)
+}
+
+// GetCoaches lists the coaches of the club with the state of their licences.
+func (s *Service) GetCoaches(ctx context.Context, clubKey string, actor *entities.User) ([]dtos.CoachLicences, error) {
+	if err := s.requireAuthorized(ctx, clubKey, actor); err != nil {
+		return nil, err
+	}
+	coaches, err := s.DB.GetCoaches(ctx, clubKey)
+	if err != nil {
+		return nil, t.Errorf("failed to load coaches: %w", err)
+	}
+	result := make([]dtos.CoachLicences, 0, len(coaches))
+	for _, c := range coaches {
+		result = append(result, coachLicences(c, time.Now()))
+	}
+	return result, nil
+}
+
+func (s *Service) requireAuthorized(ctx context.Context, clubKey string, actor *entities.User) error {
+	authorized, err := s.IsAuthorized(ctx, actor, clubKey)
+	if err != nil {
+		return t.Errorf("authorization check failed: %w", err)
+	}
+	if !authorized {
+		return t.Errorf("unauthorized: you cannot manage coaches for this club")
+	}
+	return nil
+}
+
+func coachLicences(c graph.Coach, now time.Time) dtos.CoachLicences {
+	result := dtos.CoachLicences{
+		UserKey:   c.User.Key,
+		FirstName: c.User.FirstName,
+		LastName:  c.User.LastName,
+		Status:    "none",
+		Licences:  make([]dtos.LicenceStatus, 0, len(c.Qualifications)),
+	}
+	for _, q := range c.Qualifications {
+		state := q.State(now)
+		result.Licences = append(result.Licences, dtos.LicenceStatus{
+			Key:         q.Key,
+			LicenceType: q.LicenceType,
+			Number:      q.Number,
+			IssuingBody: q.IssuingBody,
+			Expires:     q.Expires,
+			State:       state,
+		})
+		if result.Status == "none" || licenceRank[state] < licenceRank[result.Status] {
+			result.Status = state
+		}
+	}
+	return result
+}
@@ -0,0 +1,35 @@
+package club
+
+import (
+	"dpv/dpv/src/domain/entities"
+	"dpv/dpv/src/repository/graph"
+	"testing"
+	"time"
+)
+
+func TestCoachLicences(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	soon := now.AddDate(0, 1, 0)
+	past := now.AddDate(0, -1, 0)
+
+	none := coachLicences(graph.Coach{User: entities.User{FirstName: "Max"}}, now)
+	if none.Status != "none" || len(none.Licences) != 0 {
+		t.Errorf("coach without licences: got %+v", none)
+	}
+
+	coach := graph.Coach{Qualifications: []entities.Qualification{
+		{LicenceType: "Trainer B", Status: "verified", Expires: &past},
+		{LicenceType: "Trainer C", Status: "verified", Expires: &soon},
+		{LicenceType: "Erste Hilfe", Status: "pending"},
+	}}
+	got := coachLicences(coach, now)
+	if got.Status != entities.QualificationExpiring {
+		t.Errorf("got status %s, want %s", got.Status, entities.QualificationExpiring)
+	}
+	want := []string{entities.QualificationExpired, entities.QualificationExpiring, entities.QualificationUnverified}
+	for i, l := range got.Licences {
+		if l.State != want[i] {
+			t.Errorf("licence %d: got %s, want %s", i, l.State, want[i])
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package club
+
+import (
+	"context"
+	"dpv/dpv/src/domain/entities"
+	"dpv/dpv/src/repository/storage"
+	"dpv/dpv/src/repository/t"
+	"io"
+	"time"
+)
+
+// DocumentCategories lists the categories a club document can be uploaded as.
+var DocumentCategories = []string{"statutes", "registry", "tax_exemption", "other"}
+
+// DocumentListing combines a stored file with its review metadata.
+type DocumentListing struct {
+	storage.Document
+	Category string    `json:"category"`
+	Status   string    `json:"status"`
+	Comment  string    `json:"comment,omitempty"`
+	Reviewed time.Time `json:"reviewed"`
+}
+
+// UploadDocument stores a file for the club and records it as pending review.
+func (s *Service) UploadDocument(ctx context.Context, clubKey, category, filename string, content io.Reader, user *entities.User) (*entities.Document, error) {
+	if category == "" {
+		category = "other"
+	}
+	if !isDocumentCategory(category) {
+		return nil, t.Errorf("invalid document category %s", category)
+	}
+
+	stored, err := s.Storage.SaveDocument("clubs", clubKey, filename, content)
+	if err != nil {
+		return nil, t.Errorf("save document failed: %w", err)
+	}
+
+	doc := &entities.Document{
+		Filename:    stored,
+		Category:    category,
+		Status:      "pending",
+		UploaderKey: user.Key,
+	}
+	if err := s.DB.CreateDocument(ctx, clubKey, doc); err != nil {
+		return nil, t.Errorf("failed to record document: %w", err)
+	}
+	return doc, nil
+}
+
+// ListDocuments lists all stored files of a club together with their category and review state.
+// Files uploaded before categories were introduced are reported as pending documents of category "other".
+func (s *Service) ListDocuments(ctx context.Context, clubKey string) ([]DocumentListing, error) {
+	files, err := s.Storage.ListDocuments("clubs", clubKey)
+	if err != nil {
+		return nil, t.Errorf("list documents failed: %w", err)
+	}
+	docs, err := s.DB.GetDocuments(ctx, clubKey)
+	if err != nil {
+		return nil, t.Errorf("failed to load document metadata: %w", err)
+	}
+	byName := make(map[string]entities.Document, len(docs))
+	for _, d := range docs {
+		byName[d.Filename] = d
+	}
+
+	result := make([]DocumentListing, 0, len(files))
+	for _, f := range files {
+		listing := DocumentListing{Document: f, Category: "other", Status: "pending"}
+		if d, ok := byName[f.Name]; ok {
+			listing.Category = d.Category
+			listing.Status = d.Status
+			listing.Comment = d.Comment
+			listing.Reviewed = d.Reviewed
+		}
+		result = append(result, listing)
+	}
+	return result, nil
+}
+
+// ReviewDocument accepts or rejects a club document. Accepting a document sets the
+// club's verification flag for the document's category; rejecting a previously
+// accepted document clears it again unless another document of the category is accepted.
+func (s *Service) ReviewDocument(ctx context.Context, clubKey, filename string, accepted bool, comment string, reviewer *entities.User) (*entities.Document, error) {
+	if !accepted && comment == "" {
+		return nil, t.Errorf("a comment is required when rejecting a document")
+	}
+
+	doc, err := s.DB.GetDocument(ctx, clubKey, filename)
+	if err != nil {
+		return nil, t.Errorf("failed to load document for review: %w", err)
+	}
+	club, err := s.DB.GetClubByKey(ctx, clubKey)
+	if err != nil {
+		return nil, t.Errorf("failed to load club for document review: %w", err)
+	}
+
+	now := time.Now()
+	wasAccepted := doc.Status == "accepted"
+	if accepted {
+		doc.Status = "accepted"
+	} else {
+		doc.Status = "rejected"
+	}
+	doc.Comment = comment
+	doc.ReviewerKey = reviewer.Key
+	doc.Reviewed = now
+
+	if err := s.DB.UpdateDocument(ctx, doc); err != nil {
+		return nil, t.Errorf("failed to update document: %w", err)
+	}
+
+	if !accepted && wasAccepted {
+		docs, err := s.DB.GetDocuments(ctx, clubKey)
+		if err != nil {
+			return nil, t.Errorf("failed to load document metadata: %w", err)
+		}
+		if categoryAccepted(docs, doc.Category) {
+			return doc, nil
+		}
+	}
+	if accepted || wasAccepted {
+		if setVerification(club, doc.Category, accepted, now) {
+			if err := s.DB.UpdateClub(ctx, club); err != nil {
+				return nil, t.Errorf("failed to update club verification: %w", err)
+			}
+		}
+	}
+	return doc, nil
+}
+
+// categoryAccepted reports whether any document of a category is accepted.
+func categoryAccepted(docs []entities.Document, category string) bool {
+	for _, d := range docs {
+		if d.Category == category && d.Status == "accepted" {
+			return true
+		}
+	}
+	return false
+}
+
+// setVerification sets the verification flag and timestamp matching a document category.
+// It reports whether the category has a verification flag on the club.
+func setVerification(club *entities.Club, category string, ok bool, at time.Time) bool {
+	switch category {
+	case "statutes":
+		club.StatutesOK = ok
+		club.StatutesVerification = at
+	case "registry":
+		club.RegistryOK = ok
+		club.RegistryVerification = at
+	case "tax_exemption":
+		club.TaxExemptOK = ok
+		club.TaxExemptVerification = at
+	default:
+		return false
+	}
+	return true
+}
+
+func isDocumentCategory(category string) bool {
+	for _, c := range DocumentCategories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
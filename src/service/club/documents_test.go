@@ -0,0 +1,64 @@
+package club
+
+import (
+	"dpv/dpv/src/domain/entities"
+	"testing"
+	"time"
+)
+
+func TestSetVerification(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		category string
+		want     bool
+		check    func(c *entities.Club) bool
+	}{
+		{"statutes", true, func(c *entities.Club) bool { return c.StatutesOK && c.StatutesVerification.Equal(now) }},
+		{"registry", true, func(c *entities.Club) bool { return c.RegistryOK && c.RegistryVerification.Equal(now) }},
+		{"tax_exemption", true, func(c *entities.Club) bool { return c.TaxExemptOK && c.TaxExemptVerification.Equal(now) }},
+		{"other", false, func(c *entities.Club) bool { return !c.StatutesOK && !c.RegistryOK && !c.TaxExemptOK }},
+	}
+
+	for _, tt := range tests {
+		club := &entities.Club{}
+		if got := setVerification(club, tt.category, true, now); got != tt.want {
+			t.Errorf("setVerification(%q) = %v, want %v", tt.category, got, tt.want)
+		}
+		if !tt.check(club) {
+			t.Errorf("setVerification(%q) did not set the expected fields: %+v", tt.category, club)
+		}
+	}
+
+	club := &entities.Club{StatutesOK: true}
+	setVerification(club, "statutes", false, now)
+	if club.StatutesOK {
+		t.Error("setVerification should clear the flag when not ok")
+	}
+}
+
+func TestIsDocumentCategory(t *testing.T) {
+	for _, c := range DocumentCategories {
+		if !isDocumentCategory(c) {
+			t.Errorf("isDocumentCategory(%q) = false, want true", c)
+		}
+	}
+	for _, c := range []string{"", "invoice", "Statutes"} {
+		if isDocumentCategory(c) {
+			t.Errorf("isDocumentCategory(%q) = true, want false", c)
+		}
+	}
+}
+
+func TestCategoryAccepted(t *testing.T) {
+	docs := []entities.Document{
+		{Filename: "old.pdf", Category: "statutes", Status: "rejected"},
+		{Filename: "new.pdf", Category: "statutes", Status: "accepted"},
+		{Filename: "extract.pdf", Category: "registry", Status: "pending"},
+	}
+	if !categoryAccepted(docs, "statutes") {
+		t.Error("categoryAccepted(statutes) = false, want true while another document is accepted")
+	}
+	if categoryAccepted(docs, "registry") || categoryAccepted(docs, "tax_exemption") {
+		t.Error("categoryAccepted() = true without an accepted document")
+	}
+}
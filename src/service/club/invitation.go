@@ -0,0 +1,169 @@
+package club
+
+import (
+	"context"
+	"dpv/dpv/src/domain/entities"
+	"dpv/dpv/src/repository/dpv"
+	"dpv/dpv/src/repository/security"
+	"dpv/dpv/src/repository/t"
+	"dpv/dpv/src/service/email"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// InvitationValidity is how long a board invitation can be accepted.
+const InvitationValidity = 14 * 24 * time.Hour
+
+// invite creates a pending invitation to a club role for an email address and sends it.
+// An open invitation for the same address and role is renewed instead of duplicated.
+func (s *Service) invite(ctx context.Context, clubKey, emailAddr, role string, actor *entities.User) (*entities.Invitation, error) {
+	club, err := s.DB.GetClubByKey(ctx, clubKey)
+	if err != nil {
+		return nil, t.Errorf("failed to load club for invitation: %w", err)
+	}
+
+	existing, err := s.DB.GetPendingInvitations(ctx, clubKey, role, emailAddr)
+	if err != nil {
+		return nil, t.Errorf("failed to look up invitations: %w", err)
+	}
+
+	expires := time.Now().Add(InvitationValidity).Truncate(time.Second)
+	var invitation *entities.Invitation
+	if len(existing) > 0 {
+		invitation = &existing[0]
+		invitation.Expires = expires
+		invitation.InviterKey = actor.Key
+		if err := s.DB.Invitations.Update(invitation, ctx); err != nil {
+			return nil, t.Errorf("failed to renew invitation: %w", err)
+		}
+	} else {
+		invitation = &entities.Invitation{
+			ClubKey:    clubKey,
+			Email:      emailAddr,
+			Role:       role,
+			InviterKey: actor.Key,
+			Expires:    expires,
+			Status:     "pending",
+		}
+		if err := s.DB.Invitations.Create(invitation, ctx); err != nil {
+			return nil, t.Errorf("failed to create invitation: %w", err)
+		}
+	}
+
+	token, err := invitationToken(invitation)
+	if err != nil {
+		return nil, t.Errorf("could not generate invitation token: %w", err)
+	}
+	acceptURL := fmt.Sprintf("%s/dpv/invitations/%s?token=%s",
+		dpv.ConfigInstance.Settings.BaseURL, invitation.Key, url.QueryEscape(token))
+
+	emailService := email.NewService(dpv.ConfigInstance)
+	err = emailService.SendInvitationEmail(email.InvitationData{
+		Key:         invitation.Key,
+		Email:       invitation.Email,
+		Coach:       role == entities.InvitationCoach,
+		ClubName:    club.Name,
+		InviterName: strings.TrimSpace(actor.FirstName + " " + actor.LastName),
+		AcceptURL:   acceptURL,
+		ExpiryTime:  invitation.Expires,
+	})
+	if err != nil {
+		return nil, t.Errorf("failed to send invitation email: %w", err)
+	}
+	return invitation, nil
+}
+
+// ListInvitations lists the pending board and coach invitations of a club.
+func (s *Service) ListInvitations(ctx context.Context, clubKey string, actor *entities.User) ([]entities.Invitation, error) {
+	authorized, err := s.IsAuthorized(ctx, actor, clubKey)
+	if err != nil {
+		return nil, t.Errorf("authorization check failed while listing invitations: %w", err)
+	}
+	if !authorized {
+		return nil, t.Errorf("unauthorized: you cannot manage owners for this club")
+	}
+	return s.DB.GetPendingInvitations(ctx, clubKey, "", "")
+}
+
+// RevokeInvitation withdraws a pending board invitation.
+func (s *Service) RevokeInvitation(ctx context.Context, clubKey, invitationKey string, actor *entities.User) error {
+	authorized, err := s.IsAuthorized(ctx, actor, clubKey)
+	if err != nil {
+		return t.Errorf("authorization check failed while revoking invitation: %w", err)
+	}
+	if !authorized {
+		return t.Errorf("unauthorized: you cannot manage owners for this club")
+	}
+
+	invitation, err := s.DB.Invitations.Read(invitationKey, ctx)
+	if err != nil || invitation.ClubKey != clubKey {
+		return t.Errorf("invitation not found")
+	}
+	if invitation.Status != "pending" {
+		return t.Errorf("invitation is no longer pending")
+	}
+	invitation.Status = "revoked"
+	return s.DB.Invitations.Update(invitation, ctx)
+}
+
+// GetInvitation returns an open invitation if the token is valid.
+func (s *Service) GetInvitation(ctx context.Context, invitationKey, token string) (*entities.Invitation, error) {
+	invitation, err := s.DB.Invitations.Read(invitationKey, ctx)
+	if err != nil {
+		return nil, t.Errorf("invitation not found")
+	}
+	if !validInvitationToken(invitation, token) {
+		return nil, t.Errorf("invalid invitation token")
+	}
+	if !invitation.IsOpen(time.Now()) {
+		return nil, t.Errorf("invitation has expired or is no longer valid")
+	}
+	return invitation, nil
+}
+
+// AcceptInvitation adds the user as a board member or coach of the invited club. Only the invited
+// address can accept, so the user's email must match the invitation and be verified.
+func (s *Service) AcceptInvitation(ctx context.Context, invitationKey, token string, user *entities.User) (*entities.Invitation, error) {
+	invitation, err := s.GetInvitation(ctx, invitationKey, token)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkInvitee(invitation, user); err != nil {
+		return nil, err
+	}
+	if invitation.InvitedRole() == entities.InvitationCoach {
+		err = s.DB.AddCoach(ctx, invitation.ClubKey, user.Key)
+	} else {
+		err = s.DB.AddVorstand(ctx, invitation.ClubKey, user.Key)
+	}
+	if err != nil {
+		return nil, t.Errorf("failed to accept invitation: %w", err)
+	}
+	invitation.Status = "accepted"
+	invitation.AcceptedBy = user.Key
+	if err := s.DB.Invitations.Update(invitation, ctx); err != nil {
+		return nil, t.Errorf("failed to update invitation: %w", err)
+	}
+	return invitation, nil
+}
+
+// checkInvitee rejects users other than the invited one. The link alone is not enough, it may have been forwarded.
+func checkInvitee(invitation *entities.Invitation, user *entities.User) error {
+	if !strings.EqualFold(strings.TrimSpace(user.Email), strings.TrimSpace(invitation.Email)) {
+		return t.Errorf("this invitation was sent to a different email address")
+	}
+	if user.EmailVerified == nil {
+		return t.Errorf("please verify your email address before accepting the invitation")
+	}
+	return nil
+}
+
+func invitationToken(i *entities.Invitation) (string, error) {
+	return security.GenerateValidationToken("board-invitation", i.Key, i.Expires.Unix(), i.ClubKey+":"+i.Email, "", dpv.ConfigInstance.Email.ValidationSecret)
+}
+
+func validInvitationToken(i *entities.Invitation, token string) bool {
+	return security.ValidateToken("board-invitation", i.Key, i.Expires.Unix(), i.ClubKey+":"+i.Email, "", dpv.ConfigInstance.Email.ValidationSecret, token)
+}
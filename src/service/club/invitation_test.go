@@ -0,0 +1,89 @@
+package club
+
+import (
+	"dpv/dpv/src/domain/entities"
+	"dpv/dpv/src/repository/dpv"
+	"testing"
+	"time"
+)
+
+func TestInvitationToken(t *testing.T) {
+	dpv.ConfigInstance = &dpv.Config{}
+	dpv.ConfigInstance.Email.ValidationSecret = "test-secret"
+
+	invitation := &entities.Invitation{
+		Entity:  entities.Entity{Key: "inv1"},
+		ClubKey: "club1",
+		Email:   "new@example.com",
+		Expires: time.Now().Add(InvitationValidity).Truncate(time.Second),
+		Status:  "pending",
+	}
+
+	token, err := invitationToken(invitation)
+	if err != nil {
+		t.Fatalf("invitationToken failed: %v", err)
+	}
+	if !validInvitationToken(invitation, token) {
+		t.Error("token should be valid for the invitation it was issued for")
+	}
+
+	other := *invitation
+	other.ClubKey = "club2"
+	if validInvitationToken(&other, token) {
+		t.Error("token must not be valid for another club")
+	}
+
+	other = *invitation
+	other.Expires = other.Expires.Add(time.Hour)
+	if validInvitationToken(&other, token) {
+		t.Error("token must not be valid after the expiry was changed")
+	}
+}
+
+func TestInvitation_IsOpen(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		status  string
+		expires time.Time
+		want    bool
+	}{
+		{"pending", now.Add(time.Hour), true},
+		{"pending", now.Add(-time.Hour), false},
+		{"accepted", now.Add(time.Hour), false},
+		{"revoked", now.Add(time.Hour), false},
+	}
+	for _, tt := range tests {
+		i := &entities.Invitation{Status: tt.status, Expires: tt.expires}
+		if got := i.IsOpen(now); got != tt.want {
+			t.Errorf("IsOpen() for %s expiring %v = %v, want %v", tt.status, tt.expires, got, tt.want)
+		}
+	}
+}
+
+func TestCheckInvitee(t *testing.T) {
+	verified := time.Now()
+	invitation := &entities.Invitation{Email: "new@example.com"}
+	tests := []struct {
+		name    string
+		user    entities.User
+		wantErr bool
+	}{
+		{"verified invitee", entities.User{Email: "New@Example.com", EmailVerified: &verified}, false},
+		{"unverified invitee", entities.User{Email: "new@example.com"}, true},
+		{"other user", entities.User{Email: "other@example.com", EmailVerified: &verified}, true},
+	}
+	for _, tt := range tests {
+		if err := checkInvitee(invitation, &tt.user); (err != nil) != tt.wantErr {
+			t.Errorf("%s: checkInvitee() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestInvitation_InvitedRole(t *testing.T) {
+	if role := (&entities.Invitation{}).InvitedRole(); role != entities.InvitationBoard {
+		t.Errorf("invitations without a role should be board invitations, got %s", role)
+	}
+	if role := (&entities.Invitation{Role: entities.InvitationCoach}).InvitedRole(); role != entities.InvitationCoach {
+		t.Errorf("InvitedRole() = %s, want %s", role, entities.InvitationCoach)
+	}
+}
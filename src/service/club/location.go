@@ -0,0 +1,69 @@
+package club
+
+import (
+	"context"
+	"dpv/dpv/src/domain/dtos"
+	"dpv/dpv/src/domain/entities"
+	"dpv/dpv/src/repository/geo"
+	"dpv/dpv/src/repository/t"
+)
+
+// MaxNearbyRadius limits proximity searches to 500 km.
+const MaxNearbyRadius = 500
+
+// DefaultNearbyRadius is the search radius in km if none is given. Locations geocoded from a postcode
+// are the centroid of its two-digit postal region (geo/postcodes.csv) and can be 30 to 50 km off, so
+// smaller radii miss clubs that are actually close.
+const DefaultNearbyRadius = 50
+
+// FindNearby lists public directory entries within radiusKm kilometres of a point, nearest first.
+func (s *Service) FindNearby(ctx context.Context, lat, lon, radiusKm float64, limit int) ([]dtos.PublicClub, error) {
+	if !geo.ValidCoordinates(lat, lon) {
+		return nil, t.Errorf("invalid coordinates")
+	}
+	if radiusKm <= 0 || radiusKm > MaxNearbyRadius {
+		return nil, t.Errorf("radius must be between 0 and %d km", MaxNearbyRadius)
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 100
+	}
+	return s.DB.GetPublicClubsNear(ctx, lat, lon, radiusKm*1000, limit)
+}
+
+// applyLocationUpdates sets manually entered coordinates from "latitude" and "longitude".
+// Setting both to null removes the manual location so it is geocoded from the address again.
+func applyLocationUpdates(club *entities.Club, updates map[string]interface{}) error {
+	lat, hasLat := updates["latitude"]
+	lon, hasLon := updates["longitude"]
+	if hasLat || hasLon {
+		if lat == nil && lon == nil {
+			club.Location = nil
+			club.LocationSource = ""
+		} else {
+			latF, okLat := lat.(float64)
+			lonF, okLon := lon.(float64)
+			if !okLat || !okLon || !geo.ValidCoordinates(latF, lonF) {
+				return t.Errorf("latitude and longitude must both be valid coordinates")
+			}
+			club.Location = entities.NewGeoPoint(latF, lonF)
+			club.LocationSource = "manual"
+		}
+	}
+	locateClub(club)
+	return nil
+}
+
+// locateClub geocodes a club from the postcode in its address unless coordinates were entered manually.
+func locateClub(club *entities.Club) {
+	if club.LocationSource == "manual" {
+		return
+	}
+	lat, lon, ok := geo.Lookup(geo.ExtractPostcode(club.Membership.Address))
+	if !ok {
+		club.Location = nil
+		club.LocationSource = ""
+		return
+	}
+	club.Location = entities.NewGeoPoint(lat, lon)
+	club.LocationSource = "postcode"
+}
@@ -0,0 +1,46 @@
+package club
+
+import (
+	"dpv/dpv/src/domain/entities"
+	"testing"
+)
+
+func TestApplyLocationUpdates(t *testing.T) {
+	club := &entities.Club{Membership: entities.Membership{Address: "Marienplatz 1, 80331 München"}}
+
+	// Geocoded from the postcode in the address
+	if err := applyLocationUpdates(club, map[string]interface{}{}); err != nil {
+		t.Fatalf("applyLocationUpdates failed: %v", err)
+	}
+	if club.Location == nil || club.LocationSource != "postcode" {
+		t.Fatalf("expected location from postcode, got %+v (%s)", club.Location, club.LocationSource)
+	}
+
+	// Manual coordinates take precedence over the address
+	err := applyLocationUpdates(club, map[string]interface{}{"latitude": 52.52, "longitude": 13.40})
+	if err != nil {
+		t.Fatalf("applyLocationUpdates failed: %v", err)
+	}
+	if club.LocationSource != "manual" || club.Location.Lat() != 52.52 || club.Location.Lon() != 13.40 {
+		t.Errorf("expected manual location, got %+v (%s)", club.Location, club.LocationSource)
+	}
+	club.Membership.Address = "Domkloster 4, 50667 Köln"
+	applyLocationUpdates(club, map[string]interface{}{})
+	if club.Location.Lat() != 52.52 {
+		t.Error("manual location must not be overwritten by geocoding")
+	}
+
+	// Clearing the manual location geocodes again
+	applyLocationUpdates(club, map[string]interface{}{"latitude": nil, "longitude": nil})
+	if club.LocationSource != "postcode" || club.Location.Lat() == 52.52 {
+		t.Errorf("expected location from postcode after reset, got %+v (%s)", club.Location, club.LocationSource)
+	}
+
+	// Invalid input is rejected
+	if err := applyLocationUpdates(club, map[string]interface{}{"latitude": 95.0, "longitude": 13.4}); err == nil {
+		t.Error("expected error for invalid latitude")
+	}
+	if err := applyLocationUpdates(club, map[string]interface{}{"latitude": 52.5}); err == nil {
+		t.Error("expected error when longitude is missing")
+	}
+}
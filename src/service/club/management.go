@@ -2,11 +2,18 @@ package club
 
 import (
 	"context"
+	"dpv/dpv/src/domain/dtos"
 	"dpv/dpv/src/domain/entities"
 	"dpv/dpv/src/repository/graph"
 )
 
-// GetAllClubs retrieves all clubs with optional filtering and pagination.
-func (s *Service) GetAllClubs(ctx context.Context, options graph.ClubQueryOptions) ([]entities.Club, error) {
+// GetAllClubs retrieves all clubs with optional filtering, sorting and pagination,
+// together with the total number of matching clubs.
+func (s *Service) GetAllClubs(ctx context.Context, options graph.ClubQueryOptions) ([]entities.Club, int, error) {
 	return s.DB.GetClubs(ctx, options)
 }
+
+// GetPublicDirectory lists active clubs that opted in to the public directory.
+func (s *Service) GetPublicDirectory(ctx context.Context) ([]dtos.PublicClub, error) {
+	return s.DB.GetPublicClubs(ctx)
+}
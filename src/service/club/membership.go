@@ -4,6 +4,7 @@ import (
 	"context"
 	"dpv/dpv/src/domain/entities"
 	"dpv/dpv/src/repository/t"
+	"dpv/dpv/src/service/membership"
 )
 
 // Apply marks a club's membership as requested.
@@ -13,12 +14,9 @@ func (s *Service) Apply(ctx context.Context, key string, user *entities.User) er
 		return t.Errorf("failed to load club for membership application: %w", err)
 	}
 
-	m := club.GetMembership()
-	if m.Status != "inactive" && m.Status != "cancelled" {
-		return t.Errorf("cannot apply: current status is %s", m.Status)
+	if err := membership.Apply(club); err != nil {
+		return err
 	}
-
-	m.Status = "requested"
 	if err := s.DB.UpdateClub(ctx, club); err != nil {
 		return t.Errorf("failed to update club for membership application: %w", err)
 	}
@@ -32,12 +30,9 @@ func (s *Service) Approve(ctx context.Context, key string) error {
 		return t.Errorf("failed to load club for approval: %w", err)
 	}
 
-	m := club.GetMembership()
-	if m.Status != "requested" {
-		return t.Errorf("cannot approve: current status is %s", m.Status)
+	if err := membership.Approve(club); err != nil {
+		return err
 	}
-
-	m.Status = "active"
 	if err := s.DB.UpdateClub(ctx, club); err != nil {
 		return t.Errorf("failed to update club for membership approval: %w", err)
 	}
@@ -51,12 +46,9 @@ func (s *Service) Deny(ctx context.Context, key string) error {
 		return t.Errorf("failed to load club for denial: %w", err)
 	}
 
-	m := club.GetMembership()
-	if m.Status != "requested" {
-		return t.Errorf("cannot deny: current status is %s", m.Status)
+	if err := membership.Deny(club); err != nil {
+		return err
 	}
-
-	m.Status = "denied"
 	if err := s.DB.UpdateClub(ctx, club); err != nil {
 		return t.Errorf("failed to update club for membership denial: %w", err)
 	}
@@ -70,13 +62,7 @@ func (s *Service) Cancel(ctx context.Context, key string, user *entities.User) e
 		return t.Errorf("failed to load club for membership cancellation: %w", err)
 	}
 
-	m := club.GetMembership()
-	if m.Status == "active" {
-		m.Status = "cancelled"
-	} else {
-		m.Status = "inactive"
-	}
-
+	membership.Cancel(club)
 	if err := s.DB.UpdateClub(ctx, club); err != nil {
 		return t.Errorf("failed to update club for membership cancellation: %w", err)
 	}
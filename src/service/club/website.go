@@ -0,0 +1,242 @@
+package club
+
+import (
+	"bufio"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"dpv/dpv/src/domain/entities"
+	"dpv/dpv/src/repository/dpv"
+	"dpv/dpv/src/repository/t"
+	"encoding/base64"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// WellKnownPath is the location of the verification file relative to the website root.
+const WellKnownPath = "/.well-known/dpv-verification.txt"
+
+// MetaTagName is the name of the HTML meta tag carrying the verification token.
+const MetaTagName = "dpv-verification"
+
+var (
+	metaTagPattern  = regexp.MustCompile(`(?is)<meta\s[^>]*>`)
+	nameAttrPattern = regexp.MustCompile(`(?is)\bname\s*=\s*["']?` + MetaTagName + `["'\s/>]`)
+	contentPattern  = regexp.MustCompile(`(?is)\bcontent\s*=\s*["']([^"']*)["']`)
+)
+
+// maxRedirects is the number of redirects followed when fetching a website.
+const maxRedirects = 5
+
+// sharedAddressSpace is the carrier-grade NAT range (RFC 6598), not covered by net.IP.IsPrivate.
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// WebsiteVerifier checks that a website publishes a verification token.
+type WebsiteVerifier struct {
+	Client *http.Client
+}
+
+// NewWebsiteVerifier returns a verifier that only connects to public addresses, also after redirects,
+// so that board supplied URLs cannot reach loopback, link-local or private networks.
+func NewWebsiteVerifier() *WebsiteVerifier {
+	dialer := &net.Dialer{Timeout: 5 * time.Second, Control: refuseNonPublic}
+	transport := &http.Transport{
+		DialContext:           dialer.DialContext,
+		TLSHandshakeTimeout:   5 * time.Second,
+		ResponseHeaderTimeout: 5 * time.Second,
+	}
+	return &WebsiteVerifier{Client: &http.Client{
+		Timeout:       10 * time.Second,
+		Transport:     transport,
+		CheckRedirect: checkRedirect,
+	}}
+}
+
+// refuseNonPublic rejects connections to addresses that are not publicly routable. It runs after DNS
+// resolution, so host names resolving to internal addresses are refused as well.
+func refuseNonPublic(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return t.Errorf("invalid address %s", address)
+	}
+	if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+		return t.Errorf("refusing to connect to non-public address %s", host)
+	}
+	return nil
+}
+
+// checkRedirect validates every redirect target like the website URL itself.
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return t.Errorf("too many redirects")
+	}
+	return validateTarget(req.URL)
+}
+
+// validateTarget rejects URLs that are not http(s) or name a local host or non-public IP address.
+func validateTarget(u *url.URL) error {
+	host := strings.ToLower(u.Hostname())
+	if (u.Scheme != "http" && u.Scheme != "https") || host == "" {
+		return t.Errorf("invalid website URL %s", u.String())
+	}
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return t.Errorf("refusing to connect to non-public address %s", host)
+	}
+	if ip := net.ParseIP(host); ip != nil && !isPublicIP(ip) {
+		return t.Errorf("refusing to connect to non-public address %s", host)
+	}
+	return nil
+}
+
+func isPublicIP(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() && !ip.IsMulticast() && !ip.IsUnspecified() && !sharedAddressSpace.Contains(ip)
+}
+
+// Verify reports whether the website publishes the token, either in
+// /.well-known/dpv-verification.txt or in a <meta name="dpv-verification"> tag on the given page.
+func (v *WebsiteVerifier) Verify(ctx context.Context, siteURL, token string) (bool, error) {
+	u, err := NormalizeWebsite(siteURL)
+	if err != nil {
+		return false, err
+	}
+
+	wellKnown := &url.URL{Scheme: u.Scheme, Host: u.Host, Path: WellKnownPath}
+	if body, err := v.fetch(ctx, wellKnown.String()); err == nil {
+		scanner := bufio.NewScanner(strings.NewReader(body))
+		for scanner.Scan() {
+			if strings.TrimSpace(scanner.Text()) == token {
+				return true, nil
+			}
+		}
+	}
+
+	body, err := v.fetch(ctx, u.String())
+	if err != nil {
+		return false, err
+	}
+	for _, tag := range metaTagPattern.FindAllString(body, -1) {
+		if !nameAttrPattern.MatchString(tag) {
+			continue
+		}
+		if m := contentPattern.FindStringSubmatch(tag); m != nil && strings.TrimSpace(m[1]) == token {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (v *WebsiteVerifier) fetch(ctx context.Context, target string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return "", t.Errorf("invalid website request: %w", err)
+	}
+	req.Header.Set("User-Agent", "DPV-Website-Verification/1.0")
+	resp, err := v.Client.Do(req)
+	if err != nil {
+		return "", t.Errorf("could not fetch %s: %w", target, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", t.Errorf("fetching %s returned status %d", target, resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", t.Errorf("could not read %s: %w", target, err)
+	}
+	return string(body), nil
+}
+
+// NormalizeWebsite parses a website URL, defaulting to https if no scheme is given.
+func NormalizeWebsite(siteURL string) (*url.URL, error) {
+	siteURL = strings.TrimSpace(siteURL)
+	if !strings.Contains(siteURL, "://") {
+		siteURL = "https://" + siteURL
+	}
+	u, err := url.Parse(siteURL)
+	if err != nil {
+		return nil, t.Errorf("invalid website URL: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, t.Errorf("invalid website URL %s", siteURL)
+	}
+	return u, nil
+}
+
+// WebsiteToken returns the verification token a club has to publish on its website.
+func (s *Service) WebsiteToken(clubKey string) string {
+	return websiteToken(clubKey, dpv.ConfigInstance.Auth.DpvSecretKey)
+}
+
+func websiteToken(clubKey, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("website\x01" + clubKey))
+	return "dpv-" + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))[:32]
+}
+
+// VerifyWebsite checks the club website for its verification token and stores the result.
+func (s *Service) VerifyWebsite(ctx context.Context, key string, user *entities.User) (*entities.Club, error) {
+	club, err := s.GetClub(ctx, key, user)
+	if err != nil {
+		return nil, t.Errorf("failed to load club for website verification: %w", err)
+	}
+	if club.Website == "" {
+		return nil, t.Errorf("club has no website")
+	}
+	if err := s.verifyClubWebsite(ctx, club); err != nil {
+		return nil, err
+	}
+	return club, nil
+}
+
+// verifyClubWebsite checks the website and stores only the verification fields. If the website
+// cannot be fetched the stored result is kept, so a transient network error does not revoke it.
+func (s *Service) verifyClubWebsite(ctx context.Context, club *entities.Club) error {
+	ok, err := s.Verifier.Verify(ctx, club.Website, s.WebsiteToken(club.Key))
+	if err != nil {
+		return t.Errorf("website verification failed: %w", err)
+	}
+	now := time.Now()
+	if err := s.DB.UpdateClubWebsiteVerification(ctx, club.Key, ok, now); err != nil {
+		return t.Errorf("failed to update club website verification: %w", err)
+	}
+	club.WebsiteOK = ok
+	club.WebsiteVerification = now
+	return nil
+}
+
+// StartWebsiteChecks periodically re-verifies the websites of all clubs until ctx is cancelled.
+func (s *Service) StartWebsiteChecks(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.recheckWebsites(ctx)
+			}
+		}
+	}()
+}
+
+func (s *Service) recheckWebsites(ctx context.Context) {
+	clubs, err := s.DB.GetClubsWithWebsite(ctx)
+	if err != nil {
+		log.Printf("could not load clubs for website verification: %v", err)
+		return
+	}
+	for i := range clubs {
+		if err := s.verifyClubWebsite(ctx, &clubs[i]); err != nil {
+			log.Printf("website verification of club %s failed: %v", clubs[i].Key, err)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package club
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWebsiteVerifier_Verify(t *testing.T) {
+	token := websiteToken("123", "secret")
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		want    bool
+	}{
+		{
+			name: "meta tag",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/" {
+					w.Write([]byte(`<html><head><meta content="` + token + `" name="dpv-verification" /></head></html>`))
+					return
+				}
+				http.NotFound(w, r)
+			},
+			want: true,
+		},
+		{
+			name: "well-known file",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == WellKnownPath {
+					w.Write([]byte("other\n" + token + "\n"))
+					return
+				}
+				w.Write([]byte("<html></html>"))
+			},
+			want: true,
+		},
+		{
+			name: "wrong token",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`<meta name="dpv-verification" content="dpv-wrong">`))
+			},
+			want: false,
+		},
+		{
+			name: "other meta tag with token",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`<meta name="description" content="` + token + `">`))
+			},
+			want: false,
+		},
+	}
+
+	// httptest servers listen on loopback, which NewWebsiteVerifier refuses
+	v := &WebsiteVerifier{Client: &http.Client{}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			got, err := v.Verify(context.Background(), server.URL, token)
+			if err != nil {
+				t.Fatalf("Verify failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Verify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebsiteVerifier_Unreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	v := &WebsiteVerifier{Client: &http.Client{}}
+	ok, err := v.Verify(context.Background(), server.URL, "dpv-token")
+	if ok || err == nil {
+		t.Errorf("expected failure for missing site, got ok=%v err=%v", ok, err)
+	}
+}
+
+func TestWebsiteVerifier_RefusesNonPublicAddresses(t *testing.T) {
+	requested := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = true
+	}))
+	defer server.Close()
+
+	ok, err := NewWebsiteVerifier().Verify(context.Background(), server.URL, "dpv-token")
+	if ok || err == nil || requested {
+		t.Errorf("expected loopback to be refused, got ok=%v err=%v requested=%v", ok, err, requested)
+	}
+}
+
+func TestCheckRedirect(t *testing.T) {
+	for _, target := range []string{
+		"http://169.254.169.254/latest/meta-data/",
+		"http://127.0.0.1:8080/",
+		"http://[::1]/",
+		"http://10.0.0.5/",
+		"http://100.64.1.1/",
+		"http://localhost/admin",
+		"file:///etc/passwd",
+	} {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		if err := checkRedirect(req, nil); err == nil {
+			t.Errorf("checkRedirect(%s) = nil, want error", target)
+		}
+	}
+	req := httptest.NewRequest(http.MethodGet, "https://parkour-verein.de/", nil)
+	if err := checkRedirect(req, nil); err != nil {
+		t.Errorf("checkRedirect(public) = %v", err)
+	}
+	if err := checkRedirect(req, make([]*http.Request, maxRedirects)); err == nil {
+		t.Error("checkRedirect() should stop after too many redirects")
+	}
+}
+
+func TestNormalizeWebsite(t *testing.T) {
+	u, err := NormalizeWebsite("parkour-verein.de")
+	if err != nil || u.String() != "https://parkour-verein.de" {
+		t.Errorf("NormalizeWebsite() = %v, %v", u, err)
+	}
+	if _, err := NormalizeWebsite("ftp://parkour-verein.de"); err == nil {
+		t.Error("expected error for unsupported scheme")
+	}
+}
+
+func TestWebsiteToken(t *testing.T) {
+	if websiteToken("1", "secret") == websiteToken("2", "secret") {
+		t.Error("tokens of different clubs must differ")
+	}
+	if websiteToken("1", "secret") != websiteToken("1", "secret") {
+		t.Error("token must be stable")
+	}
+}
@@ -36,6 +36,20 @@ type PasswordResetData struct {
 }
 
 func (s *Service) SendEmailValidationEmail(data ValidationData) error {
+	targetEmail := data.NewEmail
+	if targetEmail == "" {
+		targetEmail = data.User.Email
+	}
+	return s.send(targetEmail, s.generateValidationEmail(data))
+}
+
+// SendPasswordResetEmail sends a password reset email to the user
+func (s *Service) SendPasswordResetEmail(data PasswordResetData) error {
+	return s.send(data.User.Email, s.generatePasswordResetEmail(data))
+}
+
+// send delivers a prepared message to a single recipient
+func (s *Service) send(to string, message string) error {
 	// Configure SMTP
 	auth := smtp.PlainAuth("",
 		s.Config.Email.SMTPUsername,
@@ -71,13 +85,7 @@ func (s *Service) SendEmailValidationEmail(data ValidationData) error {
 	if err = client.Mail(s.Config.Email.FromAddress); err != nil {
 		return fmt.Errorf("failed to set sender: %w", err)
 	}
-
-	targetEmail := data.NewEmail
-	if targetEmail == "" {
-		targetEmail = data.User.Email
-	}
-
-	if err = client.Rcpt(targetEmail); err != nil {
+	if err = client.Rcpt(to); err != nil {
 		return fmt.Errorf("failed to set recipient: %w", err)
 	}
 
@@ -86,8 +94,6 @@ func (s *Service) SendEmailValidationEmail(data ValidationData) error {
 	if err != nil {
 		return fmt.Errorf("failed to get data writer: %w", err)
 	}
-
-	message := s.generateValidationEmail(data)
 	if _, err = writer.Write([]byte(message)); err != nil {
 		return fmt.Errorf("failed to write email data: %w", err)
 	}
@@ -95,53 +101,46 @@ func (s *Service) SendEmailValidationEmail(data ValidationData) error {
 	return writer.Close()
 }
 
-// SendPasswordResetEmail sends a password reset email to the user
-func (s *Service) SendPasswordResetEmail(data PasswordResetData) error {
-	auth := smtp.PlainAuth("",
-		s.Config.Email.SMTPUsername,
-		s.Config.Email.SMTPPassword,
-		s.Config.Email.SMTPHost)
-
-	tlsConfig := &tls.Config{
-		InsecureSkipVerify: false,
-		ServerName:         s.Config.Email.SMTPHost,
-	}
-
-	conn, err := tls.Dial("tcp", fmt.Sprintf("%s:%d", s.Config.Email.SMTPHost, s.Config.Email.SMTPPort), tlsConfig)
-	if err != nil {
-		return fmt.Errorf("failed to connect to SMTP server: %w", err)
-	}
-	defer conn.Close()
+// compose builds a multipart message with a plain text and an HTML part
+func (s *Service) compose(to, subject, id, textBody, htmlBody string) string {
+	messageID := fmt.Sprintf("<%d.%s@parkour-deutschland.de>", time.Now().Unix(), id)
+	boundary := fmt.Sprintf("boundary_%d_%s", time.Now().Unix(), id)
 
-	client, err := smtp.NewClient(conn, s.Config.Email.SMTPHost)
-	if err != nil {
-		return fmt.Errorf("failed to create SMTP client: %w", err)
-	}
-	defer client.Quit()
+	return fmt.Sprintf(`Message-ID: %s
+Date: %s
+MIME-Version: 1.0
+From: %s <%s>
+To: <%s>
+Subject: %s
+Content-Type: multipart/alternative; boundary="%s"
 
-	if err = client.Auth(auth); err != nil {
-		return fmt.Errorf("SMTP authentication failed: %w", err)
-	}
+This is a multi-part message in MIME format.
 
-	if err = client.Mail(s.Config.Email.FromAddress); err != nil {
-		return fmt.Errorf("failed to set sender: %w", err)
-	}
+--%s
+Content-Type: text/plain; charset=UTF-8
+Content-Transfer-Encoding: 8bit
 
-	if err = client.Rcpt(data.User.Email); err != nil {
-		return fmt.Errorf("failed to set recipient: %w", err)
-	}
+%s
 
-	writer, err := client.Data()
-	if err != nil {
-		return fmt.Errorf("failed to get data writer: %w", err)
-	}
+--%s
+Content-Type: text/html; charset=UTF-8
+Content-Transfer-Encoding: quoted-printable
 
-	message := s.generatePasswordResetEmail(data)
-	if _, err = writer.Write([]byte(message)); err != nil {
-		return fmt.Errorf("failed to write email data: %w", err)
-	}
+%s
 
-	return writer.Close()
+--%s--`,
+		messageID,
+		time.Now().Format(time.RFC1123Z),
+		s.Config.Email.FromName,
+		s.Config.Email.FromAddress,
+		to,
+		s.encodeSubjectIfNeeded(subject),
+		boundary,
+		boundary,
+		textBody,
+		boundary,
+		s.quotedPrintableEncode(htmlBody),
+		boundary)
 }
 
 func (s *Service) generateValidationEmail(data ValidationData) string {
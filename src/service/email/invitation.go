@@ -0,0 +1,97 @@
+package email
+
+import (
+	"fmt"
+	"html"
+	"time"
+)
+
+// Data for board and coach invitation email
+type InvitationData struct {
+	Key         string // Invitation key, used for the Message-ID
+	Email       string
+	Coach       bool // Invitation to be listed as coach instead of joining the board
+	ClubName    string
+	InviterName string
+	AcceptURL   string
+	ExpiryTime  time.Time
+}
+
+// SendInvitationEmail invites a person to join a club board or to be listed as coach of a club
+func (s *Service) SendInvitationEmail(data InvitationData) error {
+	return s.send(data.Email, s.generateInvitationEmail(data))
+}
+
+func (s *Service) generateInvitationEmail(data InvitationData) string {
+	berlinLocation, _ := time.LoadLocation("Europe/Berlin")
+	expiry := data.ExpiryTime.In(berlinLocation).Format("02.01.2006 um 15:04 Uhr")
+	title := "Einladung in den Vorstand"
+	role := "den Verein %s als Vorstandsmitglied in der DPV-Mitgliederverwaltung zu vertreten."
+	if data.Coach {
+		title = "Einladung als Trainer*in"
+		role = "beim Verein %s als Trainer*in eingetragen zu werden. Der Verein sieht dann Ihre Trainerlizenzen und deren Gültigkeit."
+	}
+	subject := title + " - Deutscher Parkour Verband"
+
+	textBody := fmt.Sprintf(`DEUTSCHER PARKOUR VERBAND
+%s
+
+Hallo,
+
+%s hat Sie eingeladen, %s
+
+Um die Einladung anzunehmen, öffnen Sie bitte den folgenden Link, melden Sie sich an oder registrieren Sie sich mit dieser E-Mail-Adresse (%s):
+
+%s
+
+WICHTIG: Diese Einladung ist nur bis zum %s gültig.
+
+Falls Sie diese Einladung nicht erwartet haben, ignorieren Sie diese E-Mail einfach.
+
+© %d Deutscher Parkour Verband`,
+		title,
+		data.InviterName, fmt.Sprintf(role, `"`+data.ClubName+`"`), data.Email,
+		data.AcceptURL,
+		expiry,
+		time.Now().Year())
+
+	htmlBody := fmt.Sprintf(`<!DOCTYPE html>
+<html lang="de">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>%s</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333; max-width: 600px; margin: 0 auto; padding: 20px">
+    <div style="background-color: #2c5aa0; color: white; padding: 20px; text-align: center; border-radius: 8px 8px 0 0">
+        <h1>Deutscher Parkour Verband</h1>
+        <h2>%s</h2>
+    </div>
+    <div style="background-color: #f9f9f9; padding: 30px; border-radius: 0 0 8px 8px">
+        <p>Hallo,</p>
+        <p>%s hat Sie eingeladen, %s</p>
+        <p>Um die Einladung anzunehmen, melden Sie sich an oder registrieren Sie sich mit dieser E-Mail-Adresse (%s):</p>
+        <p style="text-align: center;">
+            <a href="%s" style="display: inline-block; background-color: #2c5aa0; color: white; padding: 12px 24px; text-decoration: none; border-radius: 5px; margin: 20px 0"><span style="color: white">Einladung annehmen</span></a>
+        </p>
+        <div style="margin-top: 20px; padding: 15px; background-color: #e3f2fd; border-radius: 5px; font-size: 14px; word-break: break-all">
+            <strong>Alternativ können Sie diesen Link kopieren und in Ihren Browser einfügen:</strong><br>
+            <a href="%s">%s</a>
+        </div>
+        <p><strong>Wichtig:</strong> Diese Einladung ist nur bis zum <strong>%s</strong> gültig.</p>
+        <p>Falls Sie diese Einladung nicht erwartet haben, ignorieren Sie diese E-Mail einfach.</p>
+    </div>
+    <div style="margin-top: 30px; padding-top: 20px; border-top: 1px solid #ddd; font-size: 12px; color: #666">
+        <p>Bei Fragen wenden Sie sich an: <a href="mailto:info@parkour-deutschland.de">info@parkour-deutschland.de</a></p>
+        <p>© %d Deutscher Parkour Verband</p>
+    </div>
+</body>
+</html>`,
+		title, title,
+		html.EscapeString(data.InviterName), fmt.Sprintf(role, "<strong>"+html.EscapeString(data.ClubName)+"</strong>"), html.EscapeString(data.Email),
+		data.AcceptURL, data.AcceptURL, data.AcceptURL,
+		expiry,
+		time.Now().Year())
+
+	return s.compose(data.Email, subject, data.Key, textBody, htmlBody)
+}
@@ -0,0 +1,73 @@
+package email
+
+import (
+	"fmt"
+	"html"
+	"time"
+)
+
+// Data for licence expiry reminder email
+type QualificationReminderData struct {
+	Key         string // Qualification key, used for the Message-ID
+	Email       string
+	Name        string
+	LicenceType string
+	Number      string
+	Expires     time.Time
+	DaysLeft    int
+}
+
+// SendQualificationReminderEmail reminds a coach that a licence is about to expire
+func (s *Service) SendQualificationReminderEmail(data QualificationReminderData) error {
+	return s.send(data.Email, s.generateQualificationReminderEmail(data))
+}
+
+func (s *Service) generateQualificationReminderEmail(data QualificationReminderData) string {
+	expires := data.Expires.Format("02.01.2006")
+	subject := "Ihre Lizenz läuft bald ab - Deutscher Parkour Verband"
+	licence := data.LicenceType
+	if data.Number != "" {
+		licence += " (" + data.Number + ")"
+	}
+
+	textBody := fmt.Sprintf(`DEUTSCHER PARKOUR VERBAND
+Ihre Lizenz läuft bald ab
+
+Hallo %s,
+
+Ihre Lizenz %s ist nur noch bis zum %s gültig (noch %d Tage).
+
+Bitte denken Sie rechtzeitig an die Verlängerung und die erforderlichen Fortbildungseinheiten. Laden Sie anschließend das neue Zertifikat in der DPV-Mitgliederverwaltung hoch.
+
+© %d Deutscher Parkour Verband`,
+		data.Name, licence, expires, data.DaysLeft,
+		time.Now().Year())
+
+	htmlBody := fmt.Sprintf(`<!DOCTYPE html>
+<html lang="de">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Ihre Lizenz läuft bald ab</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333; max-width: 600px; margin: 0 auto; padding: 20px">
+    <div style="background-color: #2c5aa0; color: white; padding: 20px; text-align: center; border-radius: 8px 8px 0 0">
+        <h1>Deutscher Parkour Verband</h1>
+        <h2>Ihre Lizenz läuft bald ab</h2>
+    </div>
+    <div style="background-color: #f9f9f9; padding: 30px; border-radius: 0 0 8px 8px">
+        <p>Hallo %s,</p>
+        <p>Ihre Lizenz <strong>%s</strong> ist nur noch bis zum <strong>%s</strong> gültig (noch %d Tage).</p>
+        <p>Bitte denken Sie rechtzeitig an die Verlängerung und die erforderlichen Fortbildungseinheiten. Laden Sie anschließend das neue Zertifikat in der DPV-Mitgliederverwaltung hoch.</p>
+    </div>
+    <div style="margin-top: 30px; padding-top: 20px; border-top: 1px solid #ddd; font-size: 12px; color: #666">
+        <p>Bei Fragen wenden Sie sich an: <a href="mailto:info@parkour-deutschland.de">info@parkour-deutschland.de</a></p>
+        <p>© %d Deutscher Parkour Verband</p>
+    </div>
+</body>
+</html>`,
+		html.EscapeString(data.Name), html.EscapeString(licence), expires, data.DaysLeft,
+		time.Now().Year())
+
+	return s.compose(data.Email, subject, data.Key, textBody, htmlBody)
+}
@@ -0,0 +1,242 @@
+package membership
+
+import (
+	"bytes"
+	"dpv/dpv/src/domain/entities"
+	"dpv/dpv/src/repository/security"
+	"dpv/dpv/src/repository/t"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/skip2/go-qrcode"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Holders of a membership card
+const (
+	CardClub = "club"
+	CardUser = "user"
+)
+
+// cardCommand binds card tokens to their purpose.
+const cardCommand = "membership-card"
+
+// Card is a membership card of an active club or individual member.
+type Card struct {
+	Type       string // club or user
+	Key        string
+	Name       string
+	ValidUntil time.Time
+	Token      string
+	VerifyURL  string // Encoded in the QR code
+}
+
+// CardValidUntil returns the end of the membership year, the last second of the calendar year in Berlin.
+func CardValidUntil(now time.Time) time.Time {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		berlin = time.UTC
+	}
+	return time.Date(now.In(berlin).Year()+1, 1, 1, 0, 0, 0, 0, berlin).Add(-time.Second)
+}
+
+// NewCard issues a membership card. Only active memberships get a card.
+func NewCard(cardType, key, name string, p entities.MembershipProvider, baseURL, secret string, now time.Time) (*Card, error) {
+	if status := p.GetMembership().Status; status != "active" {
+		return nil, t.Errorf("no membership card: membership status is %s", status)
+	}
+	validUntil := CardValidUntil(now)
+	token := CardToken(cardType, key, validUntil, secret)
+	return &Card{
+		Type:       cardType,
+		Key:        key,
+		Name:       name,
+		ValidUntil: validUntil,
+		Token:      token,
+		VerifyURL:  baseURL + "/dpv/verify/" + token,
+	}, nil
+}
+
+// CardToken returns the signed payload of a membership card.
+func CardToken(cardType, key string, expires time.Time, secret string) string {
+	return security.SignToken(cardCommand, secret, cardType, key, strconv.FormatInt(expires.Unix(), 10))
+}
+
+// ParseCardToken checks the signature and expiry of a card token and returns its holder.
+func ParseCardToken(token, secret string, now time.Time) (cardType, key string, expires time.Time, err error) {
+	fields, ok := security.VerifySignedToken(cardCommand, secret, token)
+	if !ok || len(fields) != 3 || (fields[0] != CardClub && fields[0] != CardUser) {
+		return "", "", time.Time{}, t.Errorf("invalid membership card")
+	}
+	unix, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		return "", "", time.Time{}, t.Errorf("invalid membership card")
+	}
+	expires = time.Unix(unix, 0)
+	if now.After(expires) {
+		return "", "", time.Time{}, t.Errorf("membership card has expired")
+	}
+	return fields[0], fields[1], expires, nil
+}
+
+func (c *Card) title() string {
+	if c.Type == CardClub {
+		return "Mitgliedsausweis Verein"
+	}
+	return "Mitgliedsausweis"
+}
+
+// Render returns the card as "pdf" or "png" together with its content type.
+func (c *Card) Render(format string) ([]byte, string, error) {
+	var buf bytes.Buffer
+	switch format {
+	case "pdf":
+		if err := c.RenderPDF(&buf); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), "application/pdf", nil
+	case "png":
+		if err := c.RenderPNG(&buf); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), "image/png", nil
+	default:
+		return nil, "", t.Errorf("unsupported card format %s", format)
+	}
+}
+
+// RenderPDF writes the card as a credit card sized PDF.
+func (c *Card) RenderPDF(w io.Writer) error {
+	qr, err := qrcode.Encode(c.VerifyURL, qrcode.Medium, 512)
+	if err != nil {
+		return t.Errorf("could not create QR code: %w", err)
+	}
+
+	pdf := fpdf.NewCustom(&fpdf.InitType{UnitStr: "mm", Size: fpdf.SizeType{Wd: 85.6, Ht: 54}})
+	pdf.SetMargins(0, 0, 0)
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.AddPage()
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pdf.SetFillColor(44, 90, 160)
+	pdf.Rect(0, 0, 85.6, 12, "F")
+	pdf.SetTextColor(255, 255, 255)
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.SetXY(4, 2)
+	pdf.CellFormat(78, 5, tr("Deutscher Parkour Verband"), "", 2, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 7)
+	pdf.CellFormat(78, 4, tr(c.title()), "", 0, "L", false, 0, "")
+
+	pdf.SetTextColor(51, 51, 51)
+	pdf.SetXY(4, 17)
+	pdf.SetFont("Helvetica", "B", 9)
+	pdf.MultiCell(48, 4.5, tr(c.Name), "", "L", false)
+	pdf.SetFont("Helvetica", "", 7)
+	pdf.SetXY(4, 38)
+	pdf.CellFormat(48, 4, tr("Mitgliedsnummer: "+c.Key), "", 2, "L", false, 0, "")
+	pdf.CellFormat(48, 4, tr("Gültig bis: "+c.ValidUntil.Format("02.01.2006")), "", 0, "L", false, 0, "")
+
+	pdf.RegisterImageOptionsReader("qr", fpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(qr))
+	pdf.ImageOptions("qr", 54, 15, 29, 29, false, fpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+	pdf.SetFont("Helvetica", "", 5)
+	pdf.SetXY(54, 45)
+	pdf.CellFormat(29, 3, tr("Mitgliedschaft prüfen"), "", 0, "C", false, 0, "")
+
+	if err := pdf.Output(w); err != nil {
+		return t.Errorf("could not create membership card: %w", err)
+	}
+	return nil
+}
+
+// RenderPNG writes the card as an 856x540 pixel image.
+func (c *Card) RenderPNG(w io.Writer) error {
+	qr, err := qrcode.New(c.VerifyURL, qrcode.Medium)
+	if err != nil {
+		return t.Errorf("could not create QR code: %w", err)
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, 856, 540))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(img, image.Rect(0, 0, 856, 120), image.NewUniform(color.RGBA{44, 90, 160, 255}), image.Point{}, draw.Src)
+	draw.Draw(img, image.Rect(540, 150, 820, 430), qr.Image(280), image.Point{}, draw.Src)
+
+	text := func(x, y, scale int, col color.Color, s string) {
+		drawText(img, x, y, scale, col, s)
+	}
+	dark := color.RGBA{51, 51, 51, 255}
+	text(40, 55, 3, color.White, "Deutscher Parkour Verband")
+	text(40, 100, 2, color.White, c.title())
+	name, nameScale := asciiText(c.Name), 3
+	if len(name) > 22 {
+		nameScale = 2
+	}
+	if len(name) > 34 {
+		name = name[:31] + "..."
+	}
+	text(40, 200, nameScale, dark, name)
+	text(40, 400, 2, dark, "Mitgliedsnummer: "+c.Key)
+	text(40, 440, 2, dark, "Gültig bis: "+c.ValidUntil.Format("02.01.2006"))
+	text(526, 470, 2, dark, "Mitgliedschaft prüfen") // centred below the QR code
+
+	if err := png.Encode(w, img); err != nil {
+		return t.Errorf("could not create membership card: %w", err)
+	}
+	return nil
+}
+
+// umlauts are spelled out before other diacritics are stripped, see Transliterate.
+var umlauts = strings.NewReplacer("ä", "ae", "ö", "oe", "ü", "ue", "Ä", "Ae", "Ö", "Oe", "Ü", "Ue", "ß", "ss")
+
+// Transliterate spells out umlauts and strips all other diacritics, so "Jörg Weiß, Zoë" becomes "Joerg Weiss, Zoe".
+func Transliterate(s string) string {
+	s, _, _ = transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), umlauts.Replace(s))
+	return s
+}
+
+// asciiText transliterates s to the characters covered by the bitmap font.
+func asciiText(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || r > 0x7e {
+			return '?'
+		}
+		return r
+	}, Transliterate(s))
+}
+
+// drawText draws s with its baseline at y, scaling the bitmap font by an integer factor.
+func drawText(dst draw.Image, x, y, scale int, col color.Color, s string) {
+	face := basicfont.Face7x13
+	s = asciiText(s)
+	width := font.MeasureString(face, s).Ceil()
+	if width == 0 {
+		return
+	}
+	height := face.Metrics().Height.Ceil()
+	ascent := face.Metrics().Ascent.Ceil()
+	src := image.NewRGBA(image.Rect(0, 0, width, height))
+	d := &font.Drawer{Dst: src, Src: image.NewUniform(col), Face: face, Dot: fixed.P(0, ascent)}
+	d.DrawString(s)
+	for sy := 0; sy < height; sy++ {
+		for sx := 0; sx < width; sx++ {
+			px := src.RGBAAt(sx, sy)
+			if px.A == 0 {
+				continue
+			}
+			r := image.Rect(x+sx*scale, y-ascent*scale+sy*scale, x+(sx+1)*scale, y-ascent*scale+(sy+1)*scale)
+			draw.Draw(dst, r, image.NewUniform(px), image.Point{}, draw.Over)
+		}
+	}
+}
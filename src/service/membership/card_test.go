@@ -0,0 +1,77 @@
+package membership
+
+import (
+	"bytes"
+	"dpv/dpv/src/domain/entities"
+	"image/png"
+	"testing"
+	"time"
+)
+
+func TestCardToken(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	validUntil := CardValidUntil(now)
+	if validUntil.Year() != 2025 || validUntil.Month() != time.December || validUntil.Day() != 31 {
+		t.Errorf("unexpected end of membership year: %v", validUntil)
+	}
+
+	token := CardToken(CardClub, "123", validUntil, "secret")
+	cardType, key, expires, err := ParseCardToken(token, "secret", now)
+	if err != nil || cardType != CardClub || key != "123" || !expires.Equal(validUntil) {
+		t.Fatalf("got %s %s %v %v", cardType, key, expires, err)
+	}
+
+	if _, _, _, err := ParseCardToken(token, "secret", validUntil.Add(time.Second)); err == nil {
+		t.Error("expired card accepted")
+	}
+	if _, _, _, err := ParseCardToken(token, "other-secret", now); err == nil {
+		t.Error("card with wrong signature accepted")
+	}
+	forged := CardToken("admin", "123", validUntil, "secret")
+	if _, _, _, err := ParseCardToken(forged, "secret", now); err == nil {
+		t.Error("card of unknown type accepted")
+	}
+}
+
+func TestNewCard(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	user := &entities.User{Membership: entities.Membership{Status: "requested"}}
+	if _, err := NewCard(CardUser, "42", "Jörg Müller", user, "https://example.org", "secret", now); err == nil {
+		t.Error("card issued for inactive membership")
+	}
+
+	user.Membership.Status = "active"
+	card, err := NewCard(CardUser, "42", "Jörg Müller", user, "https://example.org", "secret", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if card.VerifyURL != "https://example.org/dpv/verify/"+card.Token {
+		t.Errorf("unexpected verify URL %s", card.VerifyURL)
+	}
+
+	var pdf bytes.Buffer
+	if err := card.RenderPDF(&pdf); err != nil {
+		t.Fatalf("RenderPDF failed: %v", err)
+	}
+	if !bytes.HasPrefix(pdf.Bytes(), []byte("%PDF")) {
+		t.Error("RenderPDF did not produce a PDF")
+	}
+
+	var img bytes.Buffer
+	if err := card.RenderPNG(&img); err != nil {
+		t.Fatalf("RenderPNG failed: %v", err)
+	}
+	decoded, err := png.Decode(&img)
+	if err != nil {
+		t.Fatalf("RenderPNG did not produce a PNG: %v", err)
+	}
+	if b := decoded.Bounds(); b.Dx() != 856 || b.Dy() != 540 {
+		t.Errorf("unexpected image size %v", b)
+	}
+}
+
+func TestASCIIText(t *testing.T) {
+	if got := asciiText("Jörg Weiß, Zoë"); got != "Joerg Weiss, Zoe" {
+		t.Errorf("got %q", got)
+	}
+}
@@ -0,0 +1,106 @@
+package membership
+
+import (
+	"dpv/dpv/src/domain/entities"
+	"dpv/dpv/src/repository/t"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// The membership state machine shared by clubs and individual members:
+//
+//	inactive, cancelled -> requested (Apply)
+//	requested -> active (Approve) or denied (Deny)
+//	active -> cancelled, anything else -> inactive (Cancel)
+
+var (
+	ibanPattern    = regexp.MustCompile(`^[A-Z]{2}[0-9]{2}[A-Z0-9]{11,30}$`)
+	mandatePattern = regexp.MustCompile(`^[A-Za-z0-9+?/:().,' -]{1,35}$`)
+)
+
+// Apply marks a membership as requested.
+func Apply(p entities.MembershipProvider) error {
+	m := p.GetMembership()
+	if m.Status != "" && m.Status != "inactive" && m.Status != "cancelled" {
+		return t.Errorf("cannot apply: current status is %s", m.Status)
+	}
+	m.Status = "requested"
+	return nil
+}
+
+// Approve marks a requested membership as active.
+func Approve(p entities.MembershipProvider) error {
+	m := p.GetMembership()
+	if m.Status != "requested" {
+		return t.Errorf("cannot approve: current status is %s", m.Status)
+	}
+	m.Status = "active"
+	return nil
+}
+
+// Deny marks a requested membership as denied.
+func Deny(p entities.MembershipProvider) error {
+	m := p.GetMembership()
+	if m.Status != "requested" {
+		return t.Errorf("cannot deny: current status is %s", m.Status)
+	}
+	m.Status = "denied"
+	return nil
+}
+
+// Cancel marks an active membership as cancelled and resets any other state to inactive.
+func Cancel(p entities.MembershipProvider) {
+	m := p.GetMembership()
+	if m.Status == "active" {
+		m.Status = "cancelled"
+	} else {
+		m.Status = "inactive"
+	}
+}
+
+// SetIBAN validates and stores the IBAN for direct debits. An empty IBAN clears it.
+func SetIBAN(p entities.MembershipProvider, iban string) error {
+	normalized, err := NormalizeIBAN(iban)
+	if err != nil {
+		return err
+	}
+	p.GetMembership().IBAN = normalized
+	return nil
+}
+
+// SetSEPAMandateNumber validates and stores the SEPA mandate reference. An empty reference clears it.
+func SetSEPAMandateNumber(p entities.MembershipProvider, mandate string) error {
+	mandate = strings.TrimSpace(mandate)
+	if mandate != "" && !mandatePattern.MatchString(mandate) {
+		return t.Errorf("invalid SEPA mandate reference")
+	}
+	p.GetMembership().SEPAMandateNumber = mandate
+	return nil
+}
+
+// NormalizeIBAN removes spaces, upper-cases the IBAN and verifies its check digits.
+func NormalizeIBAN(iban string) (string, error) {
+	iban = strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(iban), " ", ""))
+	if iban == "" {
+		return "", nil
+	}
+	if !ibanPattern.MatchString(iban) {
+		return "", t.Errorf("invalid IBAN")
+	}
+	// ISO 13616: move the first four characters to the end, map letters to 10..35, check mod 97 == 1
+	var digits strings.Builder
+	for _, r := range iban[4:] + iban[:4] {
+		if r >= 'A' && r <= 'Z' {
+			digits.WriteString(strconv.Itoa(int(r-'A') + 10))
+		} else {
+			digits.WriteRune(r)
+		}
+	}
+	n, _ := new(big.Int).SetString(digits.String(), 10)
+	if new(big.Int).Mod(n, big.NewInt(97)).Int64() != 1 {
+		return "", t.Errorf("invalid IBAN")
+	}
+	return iban, nil
+}
@@ -0,0 +1,73 @@
+package membership
+
+import (
+	"dpv/dpv/src/domain/entities"
+	"testing"
+)
+
+func TestStateMachine(t *testing.T) {
+	for _, provider := range []entities.MembershipProvider{&entities.Club{}, &entities.User{}} {
+		m := provider.GetMembership()
+
+		if err := Approve(provider); err == nil {
+			t.Error("approving without an application should fail")
+		}
+		if err := Apply(provider); err != nil || m.Status != "requested" {
+			t.Fatalf("Apply: status %s, err %v", m.Status, err)
+		}
+		if err := Apply(provider); err == nil {
+			t.Error("applying twice should fail")
+		}
+		if err := Approve(provider); err != nil || m.Status != "active" {
+			t.Fatalf("Approve: status %s, err %v", m.Status, err)
+		}
+		if err := Deny(provider); err == nil {
+			t.Error("denying an active membership should fail")
+		}
+		Cancel(provider)
+		if m.Status != "cancelled" {
+			t.Errorf("Cancel of an active membership: status %s", m.Status)
+		}
+		if err := Apply(provider); err != nil {
+			t.Errorf("re-applying after cancellation should work: %v", err)
+		}
+		if err := Deny(provider); err != nil || m.Status != "denied" {
+			t.Fatalf("Deny: status %s, err %v", m.Status, err)
+		}
+		Cancel(provider)
+		if m.Status != "inactive" {
+			t.Errorf("Cancel of a denied membership: status %s", m.Status)
+		}
+	}
+}
+
+func TestNormalizeIBAN(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"DE89 3704 0044 0532 0130 00", "DE89370400440532013000", false},
+		{"de89370400440532013000", "DE89370400440532013000", false},
+		{"", "", false},
+		{"DE88 3704 0044 0532 0130 00", "", true},
+		{"DE89", "", true},
+		{"not an iban", "", true},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeIBAN(tt.input)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("NormalizeIBAN(%q) = %q, %v; want %q, error %v", tt.input, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestSetSEPAMandateNumber(t *testing.T) {
+	user := &entities.User{}
+	if err := SetSEPAMandateNumber(user, " DPV-2025-0001 "); err != nil || user.Membership.SEPAMandateNumber != "DPV-2025-0001" {
+		t.Errorf("valid mandate rejected: %v", err)
+	}
+	if err := SetSEPAMandateNumber(user, "ÄÖÜ"); err == nil {
+		t.Error("mandate with invalid characters should be rejected")
+	}
+}
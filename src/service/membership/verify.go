@@ -0,0 +1,51 @@
+package membership
+
+import (
+	"context"
+	"dpv/dpv/src/domain/dtos"
+	"dpv/dpv/src/repository/dpv"
+	"dpv/dpv/src/repository/graph"
+	"dpv/dpv/src/repository/t"
+	"strings"
+	"time"
+)
+
+type Service struct {
+	DB *graph.Db
+}
+
+func NewService(db *graph.Db) *Service {
+	return &Service{DB: db}
+}
+
+// Verify checks a membership card token and returns the current membership status of its holder.
+// Apart from the name no personal data is disclosed.
+func (s *Service) Verify(ctx context.Context, token string) (*dtos.MembershipVerification, error) {
+	cardType, key, validUntil, err := ParseCardToken(token, dpv.ConfigInstance.Auth.DpvSecretKey, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dtos.MembershipVerification{Type: cardType, ValidUntil: validUntil}
+	switch cardType {
+	case CardClub:
+		club, err := s.DB.GetClubByKey(ctx, key)
+		if err != nil {
+			return nil, t.Errorf("invalid membership card")
+		}
+		result.Name = club.Name
+		result.Status = club.Membership.Status
+		if club.Archived != nil {
+			result.Status = "archived"
+		}
+	case CardUser:
+		user, err := s.DB.Users.Read(key, ctx)
+		if err != nil {
+			return nil, t.Errorf("invalid membership card")
+		}
+		result.Name = strings.TrimSpace(user.FirstName + " " + user.LastName)
+		result.Status = user.Membership.Status
+	}
+	result.Active = result.Status == "active"
+	return result, nil
+}
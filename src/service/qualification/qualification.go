@@ -0,0 +1,194 @@
+package qualification
+
+import (
+	"context"
+	"dpv/dpv/src/api"
+	"dpv/dpv/src/domain/entities"
+	"dpv/dpv/src/repository/graph"
+	"dpv/dpv/src/repository/storage"
+	"dpv/dpv/src/repository/t"
+	"io"
+	"log"
+	"strings"
+	"time"
+)
+
+// storageType is the storage directory of certificates, one subdirectory per qualification.
+const storageType = "qualifications"
+
+type Service struct {
+	DB      *graph.Db
+	Storage *storage.Storage
+}
+
+func NewService(db *graph.Db, st *storage.Storage) *Service {
+	return &Service{DB: db, Storage: st}
+}
+
+// Create records a new qualification of the user, pending admin verification.
+func (s *Service) Create(ctx context.Context, user *entities.User, q *entities.Qualification) error {
+	if err := validate(q); err != nil {
+		return err
+	}
+	q.Status = "pending"
+	q.Certificate = ""
+	q.Comment = ""
+	q.VerifierKey = ""
+	q.Verified = time.Time{}
+	q.Reminded = 0
+	if err := s.DB.CreateQualification(ctx, user.Key, q); err != nil {
+		return t.Errorf("failed to record qualification: %w", err)
+	}
+	return nil
+}
+
+// List returns the qualifications of the user.
+func (s *Service) List(ctx context.Context, user *entities.User) ([]entities.Qualification, error) {
+	return s.DB.GetQualifications(ctx, user.Key)
+}
+
+// Update changes the details of a qualification, e.g. after a renewal. The qualification
+// has to be verified again and expiry reminders start over.
+func (s *Service) Update(ctx context.Context, user *entities.User, key string, changes *entities.Qualification) (*entities.Qualification, error) {
+	q, err := s.owned(ctx, user, key)
+	if err != nil {
+		return nil, err
+	}
+	if err := validate(changes); err != nil {
+		return nil, err
+	}
+	q.LicenceType = changes.LicenceType
+	q.Number = changes.Number
+	q.IssuingBody = changes.IssuingBody
+	q.Issued = changes.Issued
+	q.Expires = changes.Expires
+	q.RefresherHours = changes.RefresherHours
+	q.Status = "pending"
+	q.Comment = ""
+	q.VerifierKey = ""
+	q.Verified = time.Time{}
+	q.Reminded = 0
+	if err := s.DB.UpdateQualification(ctx, q); err != nil {
+		return nil, t.Errorf("failed to update qualification: %w", err)
+	}
+	return q, nil
+}
+
+// Delete removes a qualification of the user together with its certificate.
+func (s *Service) Delete(ctx context.Context, user *entities.User, key string) error {
+	q, err := s.owned(ctx, user, key)
+	if err != nil {
+		return err
+	}
+	if err := s.Storage.DeleteDocuments(storageType, q.Key); err != nil {
+		return t.Errorf("failed to delete certificate: %w", err)
+	}
+	return s.DB.DeleteQualification(ctx, q)
+}
+
+// UploadCertificate stores the certificate of a qualification, replacing a previous one.
+// A new certificate has to be verified again. The previous file is only deleted once the
+// new one is recorded, so a failed upload keeps the old certificate.
+func (s *Service) UploadCertificate(ctx context.Context, user *entities.User, key, filename string, content io.Reader) (*entities.Qualification, error) {
+	q, err := s.owned(ctx, user, key)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := s.Storage.SaveDocument(storageType, q.Key, filename, content)
+	if err != nil {
+		return nil, t.Errorf("save certificate failed: %w", err)
+	}
+	previous := q.Certificate
+	q.Certificate = stored
+	q.Status = "pending"
+	q.Comment = ""
+	q.VerifierKey = ""
+	q.Verified = time.Time{}
+	if err := s.DB.UpdateQualification(ctx, q); err != nil {
+		s.Storage.DeleteDocument(storageType, q.Key, stored)
+		return nil, t.Errorf("failed to update qualification: %w", err)
+	}
+	if previous != "" && previous != stored {
+		if err := s.Storage.DeleteDocument(storageType, q.Key, previous); err != nil {
+			log.Printf("failed to delete previous certificate of qualification %s: %v", q.Key, err)
+		}
+	}
+	return q, nil
+}
+
+// CertificatePath returns the path of the certificate file. Only the holder and admins may read it.
+func (s *Service) CertificatePath(ctx context.Context, user *entities.User, key string) (string, error) {
+	holder, err := s.DB.GetQualificationHolder(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	if holder.User.Key != user.Key && !api.IsAdmin(*user) {
+		return "", t.Errorf("unauthorized to view this certificate")
+	}
+	if holder.Qualification.Certificate == "" {
+		return "", t.Errorf("no certificate uploaded")
+	}
+	return s.Storage.GetDocumentPath(storageType, key, holder.Qualification.Certificate)
+}
+
+// Review verifies or rejects a qualification (Admin only).
+func (s *Service) Review(ctx context.Context, key string, verified bool, comment string, reviewer *entities.User) (*entities.Qualification, error) {
+	if !verified && comment == "" {
+		return nil, t.Errorf("a comment is required when rejecting a qualification")
+	}
+	holder, err := s.DB.GetQualificationHolder(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	q := &holder.Qualification
+	if verified {
+		q.Status = "verified"
+	} else {
+		q.Status = "rejected"
+	}
+	q.Comment = comment
+	q.VerifierKey = reviewer.Key
+	q.Verified = time.Now()
+	if err := s.DB.UpdateQualification(ctx, q); err != nil {
+		return nil, t.Errorf("failed to update qualification: %w", err)
+	}
+	return q, nil
+}
+
+// ListByStatus returns all qualifications with the given review status and their holders.
+func (s *Service) ListByStatus(ctx context.Context, status string) ([]graph.QualificationHolder, error) {
+	return s.DB.GetQualificationsByStatus(ctx, status)
+}
+
+func (s *Service) owned(ctx context.Context, user *entities.User, key string) (*entities.Qualification, error) {
+	holder, err := s.DB.GetQualificationHolder(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if holder.User.Key != user.Key {
+		return nil, t.Errorf("qualification not found")
+	}
+	return &holder.Qualification, nil
+}
+
+func validate(q *entities.Qualification) error {
+	q.LicenceType = strings.TrimSpace(q.LicenceType)
+	q.Number = strings.TrimSpace(q.Number)
+	q.IssuingBody = strings.TrimSpace(q.IssuingBody)
+	if q.LicenceType == "" {
+		return t.Errorf("licence type must not be empty")
+	}
+	if q.IssuingBody == "" {
+		return t.Errorf("issuing body must not be empty")
+	}
+	if q.Issued.IsZero() {
+		return t.Errorf("issue date must not be empty")
+	}
+	if q.Expires != nil && q.Expires.Before(q.Issued) {
+		return t.Errorf("expiry date must not be before issue date")
+	}
+	if q.RefresherHours < 0 {
+		return t.Errorf("refresher hours must not be negative")
+	}
+	return nil
+}
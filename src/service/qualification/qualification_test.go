@@ -0,0 +1,85 @@
+package qualification
+
+import (
+	"dpv/dpv/src/domain/entities"
+	"testing"
+	"time"
+)
+
+func date(y int, m time.Month, d int) *time.Time {
+	v := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	return &v
+}
+
+func TestValidate(t *testing.T) {
+	valid := entities.Qualification{LicenceType: " DOSB Trainer C Parkour ", IssuingBody: "DPV", Issued: *date(2024, 3, 1), Expires: date(2028, 3, 31)}
+	if err := validate(&valid); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if valid.LicenceType != "DOSB Trainer C Parkour" {
+		t.Errorf("licence type not trimmed: %q", valid.LicenceType)
+	}
+
+	cases := []entities.Qualification{
+		{IssuingBody: "DPV", Issued: *date(2024, 3, 1)},
+		{LicenceType: "Trainer C", Issued: *date(2024, 3, 1)},
+		{LicenceType: "Trainer C", IssuingBody: "DPV"},
+		{LicenceType: "Trainer C", IssuingBody: "DPV", Issued: *date(2024, 3, 1), Expires: date(2024, 2, 1)},
+		{LicenceType: "Trainer C", IssuingBody: "DPV", Issued: *date(2024, 3, 1), RefresherHours: -1},
+	}
+	for i, q := range cases {
+		if err := validate(&q); err == nil {
+			t.Errorf("case %d: expected error", i)
+		}
+	}
+}
+
+func TestState(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		q    entities.Qualification
+		want string
+	}{
+		{entities.Qualification{Status: "pending", Expires: date(2030, 1, 1)}, entities.QualificationUnverified},
+		{entities.Qualification{Status: "rejected"}, entities.QualificationRejected},
+		{entities.Qualification{Status: "verified"}, entities.QualificationValid},
+		{entities.Qualification{Status: "verified", Expires: date(2030, 1, 1)}, entities.QualificationValid},
+		{entities.Qualification{Status: "verified", Expires: date(2025, 8, 1)}, entities.QualificationExpiring},
+		{entities.Qualification{Status: "verified", Expires: date(2025, 6, 1)}, entities.QualificationExpiring}, // last valid day
+		{entities.Qualification{Status: "verified", Expires: date(2025, 5, 31)}, entities.QualificationExpired},
+	}
+	for i, c := range cases {
+		if got := c.q.State(now); got != c.want {
+			t.Errorf("case %d: got %s, want %s", i, got, c.want)
+		}
+	}
+}
+
+func TestReminderStage(t *testing.T) {
+	now := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	cases := []struct {
+		expires  *time.Time
+		status   string
+		reminded int
+		stage    int
+		daysLeft int
+	}{
+		{date(2025, 12, 1), "verified", 0, 0, 183},
+		{date(2025, 8, 30), "verified", 0, 90, 90},
+		{date(2025, 8, 30), "verified", 90, 0, 90},
+		{date(2025, 7, 1), "verified", 90, 30, 30},
+		{date(2025, 6, 5), "verified", 0, 7, 4}, // skipped stages are not sent separately
+		{date(2025, 6, 5), "verified", 7, 0, 4},
+		{date(2025, 6, 1), "verified", 30, 7, 0},
+		{date(2025, 5, 31), "verified", 0, 0, 0},
+		{date(2025, 7, 1), "pending", 0, 0, 0},
+		{nil, "verified", 0, 0, 0},
+	}
+	for i, c := range cases {
+		q := entities.Qualification{Status: c.status, Expires: c.expires, Reminded: c.reminded}
+		stage, daysLeft := reminderStage(&q, now)
+		if stage != c.stage || (stage != 0 && daysLeft != c.daysLeft) {
+			t.Errorf("case %d: got stage %d with %d days left, want %d with %d", i, stage, daysLeft, c.stage, c.daysLeft)
+		}
+	}
+}
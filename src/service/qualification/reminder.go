@@ -0,0 +1,87 @@
+package qualification
+
+import (
+	"context"
+	"dpv/dpv/src/domain/entities"
+	"dpv/dpv/src/repository/dpv"
+	"dpv/dpv/src/service/email"
+	"log"
+	"strings"
+	"time"
+)
+
+// ReminderDays are the days before expiry at which coaches are reminded, largest first.
+var ReminderDays = []int{90, 30, 7}
+
+// StartExpiryReminders emails coaches whose licences are about to expire, right away and then
+// periodically until ctx is cancelled.
+func (s *Service) StartExpiryReminders(ctx context.Context, interval time.Duration) {
+	go func() {
+		s.sendReminders(ctx, time.Now())
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.sendReminders(ctx, time.Now())
+			}
+		}
+	}()
+}
+
+func (s *Service) sendReminders(ctx context.Context, now time.Time) {
+	today := now.UTC().Truncate(24 * time.Hour)
+	holders, err := s.DB.GetExpiringQualifications(ctx, today, today.AddDate(0, 0, ReminderDays[0]))
+	if err != nil {
+		log.Printf("could not load expiring qualifications: %v", err)
+		return
+	}
+	emailService := email.NewService(dpv.ConfigInstance)
+	for i := range holders {
+		q, user := &holders[i].Qualification, &holders[i].User
+		stage, daysLeft := reminderStage(q, now)
+		if stage == 0 {
+			continue
+		}
+		err := emailService.SendQualificationReminderEmail(email.QualificationReminderData{
+			Key:         q.Key,
+			Email:       user.Email,
+			Name:        strings.TrimSpace(user.FirstName + " " + user.LastName),
+			LicenceType: q.LicenceType,
+			Number:      q.Number,
+			Expires:     *q.Expires,
+			DaysLeft:    daysLeft,
+		})
+		if err != nil {
+			log.Printf("could not send licence reminder for qualification %s: %v", q.Key, err)
+			continue
+		}
+		q.Reminded = stage
+		if err := s.DB.UpdateQualification(ctx, q); err != nil {
+			log.Printf("could not record licence reminder for qualification %s: %v", q.Key, err)
+		}
+	}
+}
+
+// reminderStage returns the reminder stage due for a qualification and the days left until expiry.
+// The stage is 0 if no reminder is due, because the licence does not expire soon, has already
+// expired or the reminder for the current stage was sent.
+func reminderStage(q *entities.Qualification, now time.Time) (int, int) {
+	if q.Status != "verified" || q.Expires == nil || q.IsExpired(now) {
+		return 0, 0
+	}
+	today := now.UTC().Truncate(24 * time.Hour)
+	daysLeft := int(q.Expires.UTC().Truncate(24*time.Hour).Sub(today).Hours() / 24)
+	stage := 0
+	for _, days := range ReminderDays {
+		if daysLeft <= days {
+			stage = days
+		}
+	}
+	if stage == 0 || (q.Reminded != 0 && q.Reminded <= stage) {
+		return 0, daysLeft
+	}
+	return stage, daysLeft
+}
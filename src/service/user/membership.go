@@ -0,0 +1,91 @@
+package user
+
+import (
+	"context"
+	"dpv/dpv/src/domain/entities"
+	"dpv/dpv/src/repository/dpv"
+	"dpv/dpv/src/repository/t"
+	"dpv/dpv/src/service/membership"
+	"strings"
+	"time"
+)
+
+// ApplyMembership marks the individual membership of the user as requested.
+func (s *Service) ApplyMembership(ctx context.Context, user *entities.User) error {
+	if err := membership.Apply(user); err != nil {
+		return err
+	}
+	if err := s.DB.Users.Update(user, ctx); err != nil {
+		return t.Errorf("failed to update user for membership application: %w", err)
+	}
+	return nil
+}
+
+// ApproveMembership marks the individual membership of a user as approved.
+func (s *Service) ApproveMembership(ctx context.Context, key string) error {
+	user, err := s.DB.Users.Read(key, ctx)
+	if err != nil {
+		return t.Errorf("failed to load user for approval: %w", err)
+	}
+	if err := membership.Approve(user); err != nil {
+		return err
+	}
+	if err := s.DB.Users.Update(user, ctx); err != nil {
+		return t.Errorf("failed to update user for membership approval: %w", err)
+	}
+	return nil
+}
+
+// DenyMembership marks the individual membership of a user as denied.
+func (s *Service) DenyMembership(ctx context.Context, key string) error {
+	user, err := s.DB.Users.Read(key, ctx)
+	if err != nil {
+		return t.Errorf("failed to load user for denial: %w", err)
+	}
+	if err := membership.Deny(user); err != nil {
+		return err
+	}
+	if err := s.DB.Users.Update(user, ctx); err != nil {
+		return t.Errorf("failed to update user for membership denial: %w", err)
+	}
+	return nil
+}
+
+// CancelMembership marks the individual membership of the user as cancelled or none.
+func (s *Service) CancelMembership(ctx context.Context, user *entities.User) error {
+	membership.Cancel(user)
+	if err := s.DB.Users.Update(user, ctx); err != nil {
+		return t.Errorf("failed to update user for membership cancellation: %w", err)
+	}
+	return nil
+}
+
+// UpdatePaymentDetails sets the IBAN and SEPA mandate number of the user's individual membership.
+// Nil values are left unchanged.
+func (s *Service) UpdatePaymentDetails(ctx context.Context, user *entities.User, iban, mandate *string) error {
+	if iban != nil {
+		if err := membership.SetIBAN(user, *iban); err != nil {
+			return err
+		}
+	}
+	if mandate != nil {
+		if err := membership.SetSEPAMandateNumber(user, *mandate); err != nil {
+			return err
+		}
+	}
+	if err := s.DB.Users.Update(user, ctx); err != nil {
+		return t.Errorf("failed to update payment details: %w", err)
+	}
+	return nil
+}
+
+// GetUsersByMembershipStatus lists users whose individual membership has the given status.
+func (s *Service) GetUsersByMembershipStatus(ctx context.Context, status string) ([]entities.User, error) {
+	return s.DB.GetUsersByMembershipStatus(ctx, status)
+}
+
+// Card issues the membership card of the user's active individual membership.
+func (s *Service) Card(user *entities.User) (*membership.Card, error) {
+	return membership.NewCard(membership.CardUser, user.Key, strings.TrimSpace(user.FirstName+" "+user.LastName), user,
+		dpv.ConfigInstance.Settings.BaseURL, dpv.ConfigInstance.Auth.DpvSecretKey, time.Now())
+}
@@ -0,0 +1,99 @@
+package user
+
+import (
+	"context"
+	"dpv/dpv/src/domain/entities"
+	"dpv/dpv/src/repository/dpv"
+	"dpv/dpv/src/repository/t"
+	"slices"
+	"strings"
+)
+
+// maxProfileValue is the maximum length of a single profile attribute.
+const maxProfileValue = 200
+
+// UpdateProfile sets the profile type of the user and replaces its type-specific attributes.
+func (s *Service) UpdateProfile(ctx context.Context, user *entities.User, profileType string, profile entities.Profile) error {
+	profileType, profile, err := validateProfile(dpv.ConfigInstance.Settings.UserTypes, profileType, profile)
+	if err != nil {
+		return err
+	}
+	if err := s.DB.UpdateUserProfile(ctx, user.Key, profileType, profile); err != nil {
+		return t.Errorf("failed to update profile: %w", err)
+	}
+	user.Type = profileType
+	user.Profile = profile
+	return nil
+}
+
+// ProfileType describes a selectable user type and its attributes.
+type ProfileType struct {
+	Type   string   `json:"type"`
+	Fields []string `json:"fields"`
+}
+
+// ProfileTypes returns the configured user types with their type-specific attributes.
+func (s *Service) ProfileTypes() []ProfileType {
+	types := make([]ProfileType, 0, len(dpv.ConfigInstance.Settings.UserTypes))
+	for _, typ := range dpv.ConfigInstance.Settings.UserTypes {
+		fields := entities.ProfileFields[typ]
+		if fields == nil {
+			fields = []string{}
+		}
+		types = append(types, ProfileType{Type: typ, Fields: fields})
+	}
+	return types
+}
+
+// DefaultUserPageSize and MaxUserPageSize bound the pages of user lists.
+const (
+	DefaultUserPageSize = 50
+	MaxUserPageSize     = 200
+)
+
+// GetUsersByType lists a page of the users of a profile type and returns the total number of such users.
+func (s *Service) GetUsersByType(ctx context.Context, profileType string, skip, limit int) ([]entities.User, int, error) {
+	if profileType == "" {
+		return nil, 0, t.Errorf("user type is required")
+	}
+	if !slices.Contains(dpv.ConfigInstance.Settings.UserTypes, profileType) {
+		return nil, 0, t.Errorf("unknown user type %s", profileType)
+	}
+	skip, limit = userPage(skip, limit)
+	return s.DB.GetUsersByType(ctx, profileType, skip, limit)
+}
+
+// userPage applies the default page size and clamps skip and limit to their valid range.
+func userPage(skip, limit int) (int, int) {
+	if skip < 0 {
+		skip = 0
+	}
+	if limit <= 0 {
+		limit = DefaultUserPageSize
+	}
+	return skip, min(limit, MaxUserPageSize)
+}
+
+// validateProfile checks the type against the configured user types and the attributes against
+// the fields of that type. Values are trimmed and empty attributes dropped.
+func validateProfile(types []string, profileType string, profile entities.Profile) (string, entities.Profile, error) {
+	profileType = strings.TrimSpace(profileType)
+	if !slices.Contains(types, profileType) {
+		return "", nil, t.Errorf("unknown user type %s", profileType)
+	}
+	cleaned := entities.Profile{}
+	for field, value := range profile {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if !entities.AllowsField(profileType, field) {
+			return "", nil, t.Errorf("field %s is not available for user type %s", field, profileType)
+		}
+		if len(value) > maxProfileValue {
+			return "", nil, t.Errorf("field %s is too long", field)
+		}
+		cleaned[field] = value
+	}
+	return profileType, cleaned, nil
+}
@@ -0,0 +1,64 @@
+package user
+
+import (
+	"context"
+	"dpv/dpv/src/domain/entities"
+	"strings"
+	"testing"
+)
+
+func TestValidateProfile(t *testing.T) {
+	types := []string{"user", "coach", "school"}
+
+	typ, profile, err := validateProfile(types, " coach ", entities.Profile{"qualification": " Trainer C ", "license_number": ""})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if typ != "coach" || len(profile) != 1 || profile["qualification"] != "Trainer C" {
+		t.Errorf("got %q %v", typ, profile)
+	}
+
+	if _, profile, err := validateProfile(types, "user", nil); err != nil || len(profile) != 0 {
+		t.Errorf("plain user: got %v, %v", profile, err)
+	}
+
+	cases := []struct {
+		typ     string
+		profile entities.Profile
+	}{
+		{"", nil},
+		{"company", nil}, // not in the configured list
+		{"school", entities.Profile{"qualification": "Trainer C"}},
+		{"user", entities.Profile{"school_name": "x"}},
+		{"school", entities.Profile{"school_name": strings.Repeat("x", maxProfileValue+1)}},
+	}
+	for _, c := range cases {
+		if _, _, err := validateProfile(types, c.typ, c.profile); err == nil {
+			t.Errorf("expected error for %q %v", c.typ, c.profile)
+		}
+	}
+}
+
+func TestUserPage(t *testing.T) {
+	tests := []struct {
+		skip, limit         int
+		wantSkip, wantLimit int
+	}{
+		{0, 0, 0, DefaultUserPageSize},
+		{-5, 20, 0, 20},
+		{100, 1000, 100, MaxUserPageSize},
+	}
+	for _, tt := range tests {
+		skip, limit := userPage(tt.skip, tt.limit)
+		if skip != tt.wantSkip || limit != tt.wantLimit {
+			t.Errorf("userPage(%d, %d) = %d, %d, want %d, %d", tt.skip, tt.limit, skip, limit, tt.wantSkip, tt.wantLimit)
+		}
+	}
+}
+
+func TestGetUsersByTypeRequiresType(t *testing.T) {
+	s := &Service{}
+	if _, _, err := s.GetUsersByType(context.Background(), "", 0, 0); err == nil {
+		t.Error("listing users without a type should be rejected")
+	}
+}